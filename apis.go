@@ -36,11 +36,17 @@ import (
 	"strconv"
 	"unicode/utf8"
 
+	"yixuan_naming/place"
 	"yixuan_naming/unihan"
 
 	"github.com/valyala/fasthttp"
 )
 
+const (
+	// MaxPlaceResults : Maxinum places return by place search
+	MaxPlaceResults = 20
+)
+
 /*
 func apiSolar(ctx *fasthttp.RequestCtx) {
 	defer func() {
@@ -271,6 +277,22 @@ func apiTraditional(ctx *fasthttp.RequestCtx) {
 	ctx.SetUserValue("_envelope_data", tsi)
 }
 
+func apiPlace(ctx *fasthttp.RequestCtx) {
+	var (
+		args  = ctx.QueryArgs()
+		input = string(args.Peek("q"))
+		limit = args.GetUintOrZero("limit")
+	)
+
+	if limit <= 0 || limit > MaxPlaceResults {
+		limit = MaxPlaceResults
+	}
+
+	ctx.SetUserValue("_envelope_data", place.Search(input, limit))
+
+	return
+}
+
 /*
  * Local variables:
  * tab-width: 4
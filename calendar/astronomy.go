@@ -0,0 +1,346 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file astronomy.go
+ * @package calendar
+ * @since 10/17/2026
+ */
+
+package calendar
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Periodic terms of Earth (truncated VSOP87, Meeus appendix III) : amplitude (1e-8), phase, frequency
+type vsopTerm struct {
+	a, b, c float64
+}
+
+const (
+	// J2000 : Julian day of 2000-01-01 12:00 TT
+	J2000 = 2451545.0
+	// JulianUnixEpoch : Julian day of 1970-01-01 00:00 UTC
+	JulianUnixEpoch = 2440587.5
+
+	solartermIterations = 20
+)
+
+var (
+	earthL = [][]vsopTerm{
+		{
+			{175347046, 0, 0}, {3341656, 4.6692568, 6283.07585}, {34894, 4.6261, 12566.1517},
+			{3497, 2.7441, 5753.3849}, {3418, 2.8289, 3.5231}, {3136, 3.6277, 77713.7715},
+			{2676, 4.4181, 7860.4194}, {2343, 6.1352, 3930.2097}, {1324, 0.7425, 11506.7698},
+			{1273, 2.0371, 529.691}, {1199, 1.1096, 1577.3435}, {990, 5.233, 5884.927},
+			{902, 2.045, 26.298}, {857, 3.508, 398.149}, {780, 1.179, 5223.694},
+			{753, 2.533, 5507.553}, {505, 4.583, 18849.228}, {492, 4.205, 775.523},
+			{357, 2.92, 0.067}, {317, 5.849, 11790.629}, {284, 1.899, 796.298},
+			{271, 0.315, 10977.079}, {243, 0.345, 5486.778}, {206, 4.806, 2544.314},
+			{205, 1.869, 5573.143}, {202, 2.458, 6069.777}, {156, 0.833, 213.299},
+			{132, 3.411, 2942.463}, {126, 1.083, 20.775}, {115, 0.645, 0.98},
+			{103, 0.636, 4694.003}, {102, 0.976, 15720.839}, {102, 4.267, 7.114},
+			{99, 6.21, 2146.17}, {98, 0.68, 155.42}, {86, 5.98, 161000.69},
+			{85, 1.3, 6275.96}, {85, 3.67, 71430.7}, {80, 1.81, 17260.15},
+			{79, 3.04, 12036.46}, {75, 1.76, 5088.63}, {74, 3.5, 3154.69},
+			{74, 4.68, 801.82}, {70, 0.83, 9437.76}, {62, 3.98, 8827.39},
+			{61, 1.82, 7084.9}, {57, 2.78, 6286.6}, {56, 4.39, 14143.5},
+			{56, 3.47, 6279.55}, {52, 0.19, 12139.55}, {52, 1.33, 1748.02},
+			{51, 0.28, 5856.48}, {49, 0.49, 1194.45}, {41, 5.37, 8429.24},
+			{41, 2.4, 19651.05}, {39, 6.17, 10447.39}, {37, 6.04, 10213.29},
+			{37, 2.57, 1059.38}, {36, 1.71, 2352.87}, {36, 1.78, 6812.77},
+			{33, 0.59, 17789.85}, {30, 0.44, 83996.85}, {30, 2.74, 1349.87},
+			{25, 3.16, 4690.48},
+		},
+		{
+			{628331966747, 0, 0}, {206059, 2.678235, 6283.07585}, {4303, 2.6351, 12566.1517},
+			{425, 1.59, 3.523}, {119, 5.796, 26.298}, {109, 2.966, 1577.344},
+			{93, 2.59, 18849.23}, {72, 1.14, 529.69}, {68, 1.87, 398.15},
+			{67, 4.41, 5507.55}, {59, 2.89, 5223.69}, {56, 2.17, 155.42},
+			{45, 0.4, 796.3}, {36, 0.47, 775.52}, {29, 2.65, 7.11},
+			{21, 5.34, 0.98}, {19, 1.85, 5486.78}, {19, 4.97, 213.3},
+			{17, 2.99, 6275.96}, {16, 0.03, 2544.31}, {16, 1.43, 2146.17},
+			{15, 1.21, 10977.08}, {12, 2.83, 1748.02}, {12, 3.26, 5088.63},
+			{12, 5.27, 1194.45}, {12, 2.08, 4694}, {11, 0.77, 553.57},
+			{10, 1.3, 6286.6}, {10, 4.24, 1349.87}, {9, 2.7, 242.73},
+			{9, 5.64, 951.72}, {8, 5.3, 2352.87}, {6, 2.65, 9437.76},
+			{6, 4.67, 4690.48},
+		},
+		{
+			{52919, 0, 0}, {8720, 1.0721, 6283.0758}, {309, 0.867, 12566.152},
+			{27, 0.05, 3.52}, {16, 5.19, 26.3}, {16, 3.68, 155.42},
+			{10, 0.76, 18849.23}, {9, 2.06, 77713.77}, {7, 0.83, 775.52},
+			{5, 4.66, 1577.34}, {4, 1.03, 7.11}, {4, 3.44, 5573.14},
+			{3, 5.14, 796.3}, {3, 6.05, 5507.55}, {3, 1.19, 242.73},
+			{3, 6.12, 529.69}, {3, 0.31, 398.15}, {3, 2.28, 553.57},
+			{2, 4.38, 5223.69}, {2, 3.75, 0.98},
+		},
+		{
+			{289, 5.844, 6283.076}, {35, 0, 0}, {17, 5.49, 12566.15},
+			{3, 5.2, 155.42}, {1, 4.72, 3.52}, {1, 5.3, 18849.23},
+			{1, 5.97, 242.73},
+		},
+		{
+			{114, 3.142, 0}, {8, 4.13, 6283.08}, {1, 3.84, 12566.15},
+		},
+		{
+			{1, 3.14, 0},
+		},
+	}
+	earthR = [][]vsopTerm{
+		{
+			{100013989, 0, 0}, {1670700, 3.0984635, 6283.07585}, {13956, 3.05525, 12566.1517},
+			{3084, 5.1985, 77713.7715}, {1628, 1.1739, 5753.3849}, {1576, 2.8469, 7860.4194},
+			{925, 5.453, 11506.77}, {542, 4.564, 3930.21}, {472, 3.661, 5884.927},
+			{346, 0.964, 5507.553}, {329, 5.9, 5223.694}, {307, 0.299, 5573.143},
+			{243, 4.273, 11790.629}, {212, 5.847, 1577.344}, {186, 5.022, 10977.079},
+			{175, 3.012, 18849.228}, {110, 5.055, 5486.778}, {98, 0.89, 6069.78},
+			{86, 5.69, 15720.84}, {86, 1.27, 161000.69}, {65, 0.27, 17260.15},
+			{63, 0.92, 529.69}, {57, 2.01, 83996.85}, {56, 5.24, 71430.7},
+			{49, 3.25, 2544.31}, {47, 2.58, 775.52}, {45, 5.54, 9437.76},
+			{43, 6.01, 6275.96}, {39, 5.36, 4694}, {38, 2.39, 8827.39},
+			{37, 0.83, 19651.05}, {37, 4.9, 12139.55}, {36, 1.67, 12036.46},
+			{35, 1.84, 2942.46}, {33, 0.24, 7084.9}, {32, 0.18, 5088.63},
+			{32, 1.78, 398.15}, {28, 1.21, 6286.6}, {28, 1.9, 6279.55},
+			{26, 4.59, 10447.39},
+		},
+		{
+			{103019, 1.10749, 6283.07585}, {1721, 1.0644, 12566.1517}, {702, 3.142, 0},
+			{32, 1.02, 18849.23}, {31, 2.84, 5507.55}, {25, 1.32, 5223.69},
+			{18, 1.42, 1577.34}, {10, 5.91, 10977.08}, {9, 1.42, 6275.96},
+			{9, 0.27, 5486.78},
+		},
+		{
+			{4359, 5.7846, 6283.0758}, {124, 5.579, 12566.152}, {12, 3.14, 0},
+			{9, 3.63, 77713.77}, {6, 1.87, 5573.14}, {3, 5.47, 18849.23},
+		},
+		{
+			{145, 4.273, 6283.076}, {7, 3.92, 12566.15},
+		},
+		{
+			{4, 2.56, 6283.08},
+		},
+	}
+
+	computedSolarterms     = make(map[int][]time.Time)
+	computedSolartermsLock sync.Mutex
+)
+
+func vsopSum(series [][]vsopTerm, tau float64) float64 {
+	var (
+		ret float64
+		p   = 1.0
+	)
+
+	for _, terms := range series {
+		var s float64
+		for _, t := range terms {
+			s += t.a * math.Cos(t.b+t.c*tau)
+		}
+
+		ret += s * p
+		p *= tau
+	}
+
+	return ret / 1e8
+}
+
+// normalizeDegrees : Angle in [0, 360)
+func normalizeDegrees(d float64) float64 {
+	d = math.Mod(d, 360)
+	if d < 0 {
+		d += 360
+	}
+
+	return d
+}
+
+// DeltaT : TT - UT in seconds (Espenak & Meeus polynomials, 1800 - 2200 and beyond)
+func DeltaT(year float64) float64 {
+	var t float64
+	switch {
+	case year < 1800:
+		t = (year - 1820) / 100
+		return -20 + 32*t*t
+	case year < 1860:
+		t = year - 1800
+		return 13.72 - 0.332447*t + 0.0068612*t*t + 0.0041116*t*t*t - 0.00037436*math.Pow(t, 4) +
+			0.0000121272*math.Pow(t, 5) - 0.0000001699*math.Pow(t, 6) + 0.000000000875*math.Pow(t, 7)
+	case year < 1900:
+		t = year - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*t*t*t - 0.0004473624*math.Pow(t, 4) + math.Pow(t, 5)/233174
+	case year < 1920:
+		t = year - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*math.Pow(t, 4)
+	case year < 1941:
+		t = year - 1920
+		return 21.20 + 0.84493*t - 0.0761*t*t + 0.0020936*t*t*t
+	case year < 1961:
+		t = year - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case year < 1986:
+		t = year - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case year < 2005:
+		t = year - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*math.Pow(t, 4) + 0.00002373599*math.Pow(t, 5)
+	case year < 2050:
+		t = year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	case year < 2150:
+		t = (year - 1820) / 100
+		return -20 + 32*t*t - 0.5628*(2150-year)
+	}
+
+	t = (year - 1820) / 100
+	return -20 + 32*t*t
+}
+
+// nutationLongitude : Nutation in longitude (degrees), accurate to 0.5"
+func nutationLongitude(T float64) float64 {
+	omega := (125.04452 - 1934.136261*T) * math.Pi / 180
+	l := (280.4665 + 36000.7698*T) * math.Pi / 180
+	lm := (218.3165 + 481267.8813*T) * math.Pi / 180
+
+	return (-17.2*math.Sin(omega) - 1.32*math.Sin(2*l) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)) / 3600
+}
+
+// nutationObliquity : Nutation in obliquity (degrees), accurate to 0.1"
+func nutationObliquity(T float64) float64 {
+	omega := (125.04452 - 1934.136261*T) * math.Pi / 180
+	l := (280.4665 + 36000.7698*T) * math.Pi / 180
+	lm := (218.3165 + 481267.8813*T) * math.Pi / 180
+
+	return (9.2*math.Cos(omega) + 0.57*math.Cos(2*l) + 0.1*math.Cos(2*lm) - 0.09*math.Cos(2*omega)) / 3600
+}
+
+// trueObliquity : Obliquity of the ecliptic with nutation (degrees)
+func trueObliquity(T float64) float64 {
+	e0 := 23.0 + 26.0/60 + (21.448-46.815*T-0.00059*T*T+0.001813*T*T*T)/3600
+
+	return e0 + nutationObliquity(T)
+}
+
+// EquationOfTime : Apparent minus mean solar time (seconds) at julian ephemeris day
+func EquationOfTime(jde float64) float64 {
+	tau := (jde - J2000) / 365250
+	T := tau * 10
+
+	// Mean longitude of the sun
+	l0 := normalizeDegrees(280.4664567 + 360007.6982779*tau + 0.03032028*tau*tau +
+		math.Pow(tau, 3)/49931 - math.Pow(tau, 4)/15300 - math.Pow(tau, 5)/2000000)
+
+	// Apparent right ascension
+	lambda := SunApparentLongitude(jde) * math.Pi / 180
+	epsilon := trueObliquity(T) * math.Pi / 180
+	alpha := normalizeDegrees(math.Atan2(math.Cos(epsilon)*math.Sin(lambda), math.Cos(lambda)) * 180 / math.Pi)
+
+	e := l0 - 0.0057183 - alpha + nutationLongitude(T)*math.Cos(epsilon)
+	e = math.Mod(e+540, 360) - 180
+
+	// 1 degree = 4 minutes
+	return e * 240
+}
+
+// SunApparentLongitude : Apparent geocentric longitude of the sun (degrees) at julian ephemeris day
+func SunApparentLongitude(jde float64) float64 {
+	tau := (jde - J2000) / 365250
+	T := tau * 10
+
+	// Heliocentric earth to geocentric sun
+	theta := vsopSum(earthL, tau)*180/math.Pi + 180
+	r := vsopSum(earthR, tau)
+
+	// FK5, nutation and aberration
+	theta += -0.09033 / 3600
+	theta += nutationLongitude(T)
+	theta += -20.4898 / 3600 / r
+
+	return normalizeDegrees(theta)
+}
+
+// julianDay : Julian day (UT) of time
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixNano())/86400e9 + JulianUnixEpoch
+}
+
+// julianTime : Time of julian day (UT)
+func julianTime(jd float64) time.Time {
+	return time.Unix(0, int64(math.Round((jd-JulianUnixEpoch)*86400))*int64(time.Second))
+}
+
+// solartermJulianDay : Julian day (UT) of the sun reaching apparent longitude near guess
+func solartermJulianDay(longitude float64, guess float64) float64 {
+	var (
+		year = 2000 + (guess-J2000)/365.25
+		dt   = DeltaT(year) / 86400
+		jde  = guess + dt
+	)
+
+	for i := 0; i < solartermIterations; i++ {
+		diff := longitude - SunApparentLongitude(jde)
+		diff = math.Mod(diff+540, 360) - 180
+		step := diff * 365.2422 / 360
+		jde += step
+		if math.Abs(step) < 1e-7 {
+			break
+		}
+	}
+
+	return jde - dt
+}
+
+// ComputeSolarterms : Astronomical solarterms (XiaoHan to DongZhi) of year, cached
+func ComputeSolarterms(year int) []time.Time {
+	computedSolartermsLock.Lock()
+	defer computedSolartermsLock.Unlock()
+
+	if ret, ok := computedSolarterms[year]; ok {
+		return ret
+	}
+
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	ret := make([]time.Time, 24)
+	start := julianDay(time.Date(year, 1, 6, 0, 0, 0, 0, time.UTC))
+	for s := 0; s < 24; s++ {
+		// XiaoHan at 285 degrees, about 15.2 days each
+		jd := solartermJulianDay(normalizeDegrees(285+15*float64(s)), start+float64(s)*365.2422/24)
+		ret[s] = julianTime(jd).In(loc)
+	}
+
+	computedSolarterms[year] = ret
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -31,6 +31,7 @@
 package calendar
 
 import (
+	"math"
 	"time"
 
 	"yixuan_naming/utils"
@@ -65,19 +66,23 @@ type Calendar struct {
 	UTCTime     timeSpec       `json:"utc_time"`
 	ChinaTime   timeSpec       `json:"china_time"`
 	LocalTime   timeSpec       `json:"local_time"`
+	CivilTime   timeSpec       `json:"civil_time"`
 	RealTime    timeSpec       `json:"real_time"`
+	RealSun     realSunSpec    `json:"real_sun"`
 	Solar       solar          `json:"solar"`
 	Lunar       lunar          `json:"lunar"`
 	Ganzhi      ganzhi         `json:"ganzhi"`
 }
 
-// New : Create new calendar
-func New(timestamp int64, loc utils.Location) *Calendar {
+// New : Create new calendar, with Zi hour convention of day pillar
+func New(timestamp int64, loc utils.Location, ziHour int) *Calendar {
 	ret := &Calendar{
 		t:        time.Unix(timestamp, 0),
 		Location: loc,
 	}
 
+	ret.Location.Longitude = utils.NormalizeLongitude(loc.Longitude)
+
 	var (
 		tChina = ret.China()
 		tReal  = ret.Real()
@@ -87,11 +92,17 @@ func New(timestamp int64, loc utils.Location) *Calendar {
 	ret.UTCTime.parse(ret.UTC())
 	ret.ChinaTime.parse(tChina)
 	ret.LocalTime.parse(ret.Local())
+	ret.CivilTime.parse(ret.Civil())
 	ret.RealTime.parse(tReal)
+	ret.RealSun = ret.RealSunOffsets()
 
 	ret.Solar = solar{t: &tChina}
 	ret.Lunar = lunar{t: &tChina}
-	ret.Ganzhi = ganzhi{t: &tReal}
+	if ziHour != ZiHourLateZi {
+		ziHour = ZiHourChangeDay
+	}
+
+	ret.Ganzhi = ganzhi{t: &tReal, ZiHour: ziHour}
 
 	ret.Solar.complete()
 	ret.Lunar.complete()
@@ -123,114 +134,67 @@ func (c *Calendar) Local() time.Time {
 	return c.t.In(l)
 }
 
-var (
-	realSunFixLeap = []int{
-		0,
-		-189, -218, -246, -273, -301, -327, -354, -380, -405, -430,
-		-455, -479, -502, -525, -547, -568, -589, -609, -628, -647,
-		-665, -682, -698, -714, -728, -742, -755, -779, -790, -799,
-		-817, -824, -830, -836, -841, -845, -849, -851, -853, -854,
-		-855, -854, -853, -851, -848, -845, -841, -836, -831, -824,
-		-818, -810, -802, -793, -664, -774, -763, -752, -741, -728,
-		-716, -703, -689, -675, -661, -647, -632, -616, -601, -585,
-		-568, -552, -535, -518, -501, -484, -466, -449, -431, -413,
-		-395, -377, -358, -340, -322, -304, -285, -267, -249, -231,
-		-213, -196, -178, -161, -144, -127, -110, -93, -77, -61,
-		46, 30, 16, 1, 13, 27, 41, 54, 66, 79,
-		91, 102, 113, 124, 134, 143, 153, 161, 169, 177,
-		184, 70, 196, 201, 206, 210, 217, 216, 219, 220,
-		222, 222, 222, 222, 221, 219, 217, 214, 211, 207,
-		203, 198, 193, 187, 181, 174, 167, 159, 151, 142,
-		133, 124, 114, 104, 94, 83, 72, 60, 48, 36,
-		24, 12, 1, 14, 39, 52, -65, -78, -91, -105,
-		-117, -130, -143, -156, -168, -181, -193, -205, -217, -229,
-		-240, -251, -262, -273, -283, -293, -302, -311, -320, -328,
-		-336, -343, -350, -356, -362, -368, -372, -376, -380, -383,
-		-385, -387, -389, -389, -389, -389, -388, -386, -384, -381,
-		-377, -373, -368, -363, -357, -351, -344, -336, -328, -319,
-		-310, -300, -290, -279, -267, -255, -242, -229, -216, -201,
-		-187, -171, -156, -140, -123, -107, -89, -72, 54, 35,
-		17, 2, 21, 41, 60, 80, 100, 121, 141, 162,
-		183, 183, 204, 225, 246, 267, 288, 310, 331, 353,
-		374, 395, 417, 438, 459, 480, 501, 522, 542, 562,
-		582, 602, 621, 640, 659, 678, 696, 696, 713, 731,
-		748, 764, 780, 796, 796, 811, 825, 839, 853, 866,
-		878, 890, 901, 912, 921, 931, 940, 948, 955, 961,
-		967, 972, 976, 980, 982, 984, 985, 985, 984, 983,
-		981, 977, 973, 969, 963, 956, 949, 941, 932, 922,
-		911, 900, 887, 874, 860, 846, 830, 814, 797, 779,
-		760, 741, 721, 700, 678, 656, 633, 609, 585, 561,
-		535, 509, 483, 456, 429, 402, 374, 346, 317, 288,
-		259, 230, 201, 171, 142, 112, 82, 52, 23, 7,
-		37, -66, -96, -125, -154, -183,
+// Civil : Civil time of location zone, CST/CDT if zone unknown
+func (c *Calendar) Civil() time.Time {
+	if c.Location.Zone != "" {
+		l, err := time.LoadLocation(c.Location.Zone)
+		if err == nil {
+			return c.t.In(l)
+		}
 	}
-	realSunFixNormal = []int{
-		0,
-		-189, -218, -246, -273, -301, -327, -354, -380, -405, -430,
-		-455, -479, -502, -525, -547, -568, -589, -609, -628, -647,
-		-665, -682, -698, -714, -728, -742, -755, -779, -790, -799,
-		-817, -824, -830, -836, -841, -845, -849, -851, -853, -854,
-		-855, -854, -853, -851, -848, -845, -841, -836, -831, -824,
-		-818, -810, -802, -793, -664, -774, -763, -752, -741,
-		-716, -703, -689, -675, -661, -647, -632, -616, -601, -585,
-		-568, -552, -535, -518, -501, -484, -466, -449, -431, -413,
-		-395, -377, -358, -340, -322, -304, -285, -267, -249, -231,
-		-213, -196, -178, -161, -144, -127, -110, -93, -77, -61,
-		46, 30, 16, 1, 13, 27, 41, 54, 66, 79,
-		91, 102, 113, 124, 134, 143, 153, 161, 169, 177,
-		184, 70, 196, 201, 206, 210, 217, 216, 219, 220,
-		222, 222, 222, 222, 221, 219, 217, 214, 211, 207,
-		203, 198, 193, 187, 181, 174, 167, 159, 151, 142,
-		133, 124, 114, 104, 94, 83, 72, 60, 48, 36,
-		24, 12, 1, 14, 39, 52, -65, -78, -91, -105,
-		-117, -130, -143, -156, -168, -181, -193, -205, -217, -229,
-		-240, -251, -262, -273, -283, -293, -302, -311, -320, -328,
-		-336, -343, -350, -356, -362, -368, -372, -376, -380, -383,
-		-385, -387, -389, -389, -389, -389, -388, -386, -384, -381,
-		-377, -373, -368, -363, -357, -351, -344, -336, -328, -319,
-		-310, -300, -290, -279, -267, -255, -242, -229, -216, -201,
-		-187, -171, -156, -140, -123, -107, -89, -72, 54, 35,
-		17, 2, 21, 41, 60, 80, 100, 121, 141, 162,
-		183, 183, 204, 225, 246, 267, 288, 310, 331, 353,
-		374, 395, 417, 438, 459, 480, 501, 522, 542, 562,
-		582, 602, 621, 640, 659, 678, 696, 696, 713, 731,
-		748, 764, 780, 796, 796, 811, 825, 839, 853, 866,
-		878, 890, 901, 912, 921, 931, 940, 948, 955, 961,
-		967, 972, 976, 980, 982, 984, 985, 985, 984, 983,
-		981, 977, 973, 969, 963, 956, 949, 941, 932, 922,
-		911, 900, 887, 874, 860, 846, 830, 814, 797, 779,
-		760, 741, 721, 700, 678, 656, 633, 609, 585, 561,
-		535, 509, 483, 456, 429, 402, 374, 346, 317, 288,
-		259, 230, 201, 171, 142, 112, 82, 52, 23, 7,
-		37, -66, -96, -125, -154, -183,
+
+	return c.China()
+}
+
+// realSunSpec : Derivation of real-sun time from UTC
+type realSunSpec struct {
+	Longitude       float64 `json:"longitude"`
+	LongitudeOffset int     `json:"longitude_offset"`
+	EquationOfTime  int     `json:"equation_of_time"`
+	Offset          int     `json:"offset"`
+}
+
+// RealSunOffsets : Offsets (seconds) of real-sun time, mean solar time by longitude plus equation of time
+func (c *Calendar) RealSunOffsets() realSunSpec {
+	jd := julianDay(c.t)
+	year := 2000 + (jd-J2000)/365.25
+	spec := realSunSpec{
+		Longitude:       c.Location.Longitude,
+		LongitudeOffset: int(math.Round(c.Location.Longitude * 240)),
+		EquationOfTime:  int(math.Round(EquationOfTime(jd + DeltaT(year)/86400))),
 	}
-)
+
+	spec.Offset = spec.LongitudeOffset + spec.EquationOfTime
+
+	return spec
+}
 
 // Real : Real-sun time
 func (c *Calendar) Real() time.Time {
-	// Find index
-	leap := false
-	y := c.t.Year()
-	if y%400 == 0 {
-		// Leap
-		leap = true
-	} else if y%4 == 0 && y%100 != 0 {
-		// Leap
-		leap = true
-	}
+	l := time.FixedZone("RealSunTime", c.RealSunOffsets().Offset)
 
-	d := c.t.YearDay()
-	fix := 0
-	if leap {
-		fix = realSunFixLeap[d]
-	} else {
-		fix = realSunFixNormal[d]
-	}
+	return c.t.In(l)
+}
 
-	offset := int(c.Location.Longitude*240) + fix
-	l := time.FixedZone("RealSunTime", offset)
+// RealSunTimestamp : Timestamp of wall time in real-sun time of location, for birth time given by ShiChen
+func RealSunTimestamp(year, month, day, hour int, loc utils.Location) int64 {
+	c := &Calendar{Location: loc}
+	c.Location.Longitude = utils.NormalizeLongitude(loc.Longitude)
+	c.t = time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC)
+
+	// Equation of time moves slowly, offset settles within few rounds
+	offset := 0
+	for i := 0; i < 5; i++ {
+		c.t = time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.FixedZone("RealSunTime", offset))
+		next := c.RealSunOffsets().Offset
+		if next == offset {
+			break
+		}
+
+		offset = next
+	}
 
-	return c.t.In(l)
+	return c.t.Unix()
 }
 
 /*
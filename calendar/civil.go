@@ -0,0 +1,115 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file civil.go
+ * @package calendar
+ * @since 10/17/2026
+ */
+
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultZone : Zone of civil time if not given
+	DefaultZone = "Asia/Shanghai"
+)
+
+var civilLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// ParseCivil : Timestamp of civil (wall clock) time in IANA zone, historical DST included.
+// A wall clock skipped by DST is an error, a repeated one resolves to the earlier instant unless later set
+func ParseCivil(value, zone string, later bool) (int64, error) {
+	var (
+		w       time.Time
+		l       *time.Location
+		err     error
+		offsets = make(map[int]bool)
+		matches []int64
+	)
+
+	if zone == "" {
+		zone = DefaultZone
+	}
+
+	l, err = time.LoadLocation(zone)
+	if err != nil {
+		return 0, fmt.Errorf("Unknown time zone <%s>", zone)
+	}
+
+	for _, layout := range civilLayouts {
+		w, err = time.Parse(layout, value)
+		if err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("Invalid civil time <%s>", value)
+	}
+
+	// Offsets around the wall clock, both sides of any transition
+	guess := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), 0, l)
+	for _, d := range []time.Duration{-12 * time.Hour, 0, 12 * time.Hour} {
+		_, offset := guess.Add(d).Zone()
+		if offsets[offset] {
+			continue
+		}
+
+		offsets[offset] = true
+		u := w.Add(-time.Duration(offset) * time.Second)
+		if u.In(l).Format(civilLayouts[0]) == w.Format(civilLayouts[0]) {
+			matches = append(matches, u.Unix())
+		}
+	}
+
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("Civil time <%s> does not exist in <%s>, skipped by daylight saving", value, zone)
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i] < matches[j] })
+	if later {
+		return matches[len(matches)-1], nil
+	}
+
+	return matches[0], nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
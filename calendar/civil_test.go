@@ -0,0 +1,102 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file civil_test.go
+ * @package calendar
+ * @since 10/17/2026
+ */
+
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseCivil : Wall clocks around DST transitions, gaps rejected and folds resolved by later
+func TestParseCivil(t *testing.T) {
+	cases := []struct {
+		value string
+		zone  string
+		later bool
+		want  string // UTC, empty for error
+	}{
+		// Ordinary wall clock
+		{"2000-01-01 08:00", "", false, "2000-01-01T00:00:00Z"},
+		{"2021-07-01T12:00", "America/New_York", true, "2021-07-01T16:00:00Z"},
+
+		// New York spring forward, 2021-03-14 02:00 EST -> 03:00 EDT
+		{"2021-03-14 01:59", "America/New_York", false, "2021-03-14T06:59:00Z"},
+		{"2021-03-14 02:30", "America/New_York", false, ""},
+		{"2021-03-14 02:30", "America/New_York", true, ""},
+		{"2021-03-14 03:00", "America/New_York", false, "2021-03-14T07:00:00Z"},
+
+		// New York fall back, 2021-11-07 02:00 EDT -> 01:00 EST
+		{"2021-11-07 01:30", "America/New_York", false, "2021-11-07T05:30:00Z"},
+		{"2021-11-07 01:30", "America/New_York", true, "2021-11-07T06:30:00Z"},
+		{"2021-11-07 02:30", "America/New_York", true, "2021-11-07T07:30:00Z"},
+
+		// Shanghai spring forward, 1988-04-17 02:00 CST -> 03:00 CDT
+		{"1988-04-17 02:30", "Asia/Shanghai", false, ""},
+		{"1988-04-17 03:30", "Asia/Shanghai", false, "1988-04-16T18:30:00Z"},
+
+		// Shanghai fall back, 1988-09-11 02:00 CDT -> 01:00 CST
+		{"1988-09-11 01:30", "Asia/Shanghai", false, "1988-09-10T16:30:00Z"},
+		{"1988-09-11 01:30", "Asia/Shanghai", true, "1988-09-10T17:30:00Z"},
+		{"1988-09-11 00:30", "Asia/Shanghai", true, "1988-09-10T15:30:00Z"},
+
+		// Malformed input
+		{"1988-09-11", "Asia/Shanghai", false, ""},
+		{"2000-01-01 08:00", "Mars/Olympus", false, ""},
+	}
+
+	for _, c := range cases {
+		ts, err := ParseCivil(c.value, c.zone, c.later)
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("<%s> in <%s> (later %v) gives %s, want error", c.value, c.zone, c.later, time.Unix(ts, 0).UTC().Format(time.RFC3339))
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("<%s> in <%s> (later %v): %s", c.value, c.zone, c.later, err)
+			continue
+		}
+
+		if got := time.Unix(ts, 0).UTC().Format(time.RFC3339); got != c.want {
+			t.Errorf("<%s> in <%s> (later %v) gives %s, want %s", c.value, c.zone, c.later, got, c.want)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
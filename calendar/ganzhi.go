@@ -36,17 +36,27 @@ import (
 	"yixuan_naming/utils"
 )
 
+// Zi hour (23:00 - 01:00) conventions
+const (
+	// ZiHourChangeDay : Day pillar changes at 23:00
+	ZiHourChangeDay = iota
+	// ZiHourLateZi : Late Zi hour (23:00 - 24:00) keeps the day pillar, hour stem from next day
+	ZiHourLateZi
+)
+
 type ganzhi struct {
-	t           *time.Time
-	YearOrder   int              `json:"year_order"`
-	Year        utils.GanzhiPair `json:"year"`
-	YearString  string           `json:"year_alias"`
-	Month       utils.GanzhiPair `json:"month"`
-	MonthString string           `json:"month_alias"`
-	Day         utils.GanzhiPair `json:"day"`
-	DayString   string           `json:"day_alias"`
-	Hour        utils.GanzhiPair `json:"hour"`
-	HourString  string           `json:"hour_alias"`
+	t            *time.Time
+	ZiHour       int              `json:"zi_hour"`
+	ZiHourString string           `json:"zi_hour_alias"`
+	YearOrder    int              `json:"year_order"`
+	Year         utils.GanzhiPair `json:"year"`
+	YearString   string           `json:"year_alias"`
+	Month        utils.GanzhiPair `json:"month"`
+	MonthString  string           `json:"month_alias"`
+	Day          utils.GanzhiPair `json:"day"`
+	DayString    string           `json:"day_alias"`
+	Hour         utils.GanzhiPair `json:"hour"`
+	HourString   string           `json:"hour_alias"`
 }
 
 // GetSolartermsGanzhi : Get solarterms from LiChun to DaHan for a whole ganzhi year
@@ -61,6 +71,25 @@ func GetSolartermsGanzhi(year int) []time.Time {
 	return ret
 }
 
+// Jie : Previous and next jie (solarterm starting a ganzhi month) around the time
+func (g *ganzhi) Jie() (time.Time, time.Time) {
+	var terms []time.Time
+	for year := g.YearOrder - 1; year <= g.YearOrder+1; year++ {
+		solarterms := GetSolartermsGanzhi(year)
+		for idx := 0; idx < len(solarterms); idx += 2 {
+			terms = append(terms, solarterms[idx])
+		}
+	}
+
+	for idx := 1; idx < len(terms); idx++ {
+		if g.t.Before(terms[idx]) {
+			return terms[idx-1], terms[idx]
+		}
+	}
+
+	return terms[len(terms)-2], terms[len(terms)-1]
+}
+
 func (g *ganzhi) complete() {
 	var (
 		idx, year, month       int
@@ -108,7 +137,7 @@ func (g *ganzhi) complete() {
 		dI = 6
 	}
 	dH = g.t.Hour()
-	if dH >= 23 {
+	if dH >= 23 && g.ZiHour == ZiHourChangeDay {
 		dD++
 	}
 
@@ -118,6 +147,10 @@ func (g *ganzhi) complete() {
 
 	g.Hour.DiZhi = ((g.t.Hour() + 1) / 2) % 12
 	g.Hour.TianGan = (g.Hour.DiZhi + g.Day.TianGan*2) % 10
+	if dH >= 23 && g.ZiHour == ZiHourLateZi {
+		// Stem of Zi hour in next day
+		g.Hour.TianGan = (g.Hour.DiZhi + (g.Day.TianGan+1)*2) % 10
+	}
 	//g.HourString = g.Hour.String()
 
 	return
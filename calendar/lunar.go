@@ -31,6 +31,7 @@
 package calendar
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -177,7 +178,7 @@ func (l *lunar) complete() {
 			l.LeapMonth = true
 		}
 
-		if m > y.leapMonth {
+		if m >= y.leapMonth {
 			l.Month = m
 		}
 	}
@@ -200,6 +201,62 @@ func (l *lunar) complete() {
 	return
 }
 
+// LunarToSolar : Solar date (CST) of lunar date, inverse of lunar.complete
+func LunarToSolar(year, month int, leap bool, day int) (int, int, int, error) {
+	var (
+		y     *lunarYear
+		idx   int
+		total int
+	)
+
+	if year < lunarYears[0].year || year > lunarYears[len(lunarYears)-1].year {
+		return 0, 0, 0, fmt.Errorf("Lunar year %d out of range %d - %d", year, lunarYears[0].year, lunarYears[len(lunarYears)-1].year)
+	}
+
+	if month < 1 || month > 12 {
+		return 0, 0, 0, fmt.Errorf("Invalid lunar month %d", month)
+	}
+
+	y = lunarYears[year-lunarYears[0].year]
+	if leap && y.leapMonth != month {
+		return 0, 0, 0, fmt.Errorf("No leap month %d in lunar year %d", month, year)
+	}
+
+	// Leap month follows its ordinary month
+	idx = month - 1
+	if y.leapMonth > 0 && (month > y.leapMonth || (leap && month == y.leapMonth)) {
+		idx++
+	}
+
+	if day < 1 || day > y.days[idx] {
+		return 0, 0, 0, fmt.Errorf("Lunar month %d of year %d has only %d days", month, year, y.days[idx])
+	}
+
+	for _, v := range lunarYears[:year-lunarYears[0].year] {
+		total += v.totalDays
+	}
+
+	for _, v := range y.days[:idx] {
+		total += v
+	}
+
+	total += day - 1
+
+	// Start of lunar calendar is 00:00 CST
+	t := lunarStart.Add(time.Duration(total) * 24 * time.Hour).Add(8 * time.Hour)
+
+	return t.Year(), int(t.Month()), t.Day(), nil
+}
+
+// ShichenHour : Middle hour of ShiChen, 0 (Zi) - 11 (Hai)
+func ShichenHour(shichen int) (int, error) {
+	if shichen < 0 || shichen > 11 {
+		return 0, fmt.Errorf("Invalid shichen %d", shichen)
+	}
+
+	return shichen * 2, nil
+}
+
 // Parse all vars
 func init() {
 	for m := 0; m <= 200; m++ {
@@ -0,0 +1,113 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file lunar_test.go
+ * @package calendar
+ * @since 10/17/2026
+ */
+
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"yixuan_naming/utils"
+)
+
+// lunarOf : Lunar date of solar date (CST) at noon
+func lunarOf(year, month, day int) lunar {
+	l, _ := time.LoadLocation("Asia/Shanghai")
+	ts := time.Date(year, time.Month(month), day, 12, 0, 0, 0, l).Unix()
+
+	return New(ts, utils.Location{Longitude: 120, Latitude: 30}, ZiHourChangeDay).Lunar
+}
+
+// TestLunarLeapMonth : Leap month reported as the month it repeats
+func TestLunarLeapMonth(t *testing.T) {
+	cases := []struct {
+		year, month, day int
+		lunarMonth       int
+		leap             bool
+		lunarDay         int
+	}{
+		// Lunar 2020 has leap 4th month, 2020-05-23 - 2020-06-20
+		{2020, 5, 22, 4, false, 30},
+		{2020, 5, 23, 4, true, 1},
+		{2020, 6, 20, 4, true, 29},
+		{2020, 6, 21, 5, false, 1},
+		// Lunar 2023 has leap 2nd month, 2023-03-22 - 2023-04-19
+		{2023, 3, 22, 2, true, 1},
+		{2023, 4, 20, 3, false, 1},
+	}
+
+	for _, c := range cases {
+		l := lunarOf(c.year, c.month, c.day)
+		if l.Month != c.lunarMonth || l.LeapMonth != c.leap || l.Day != c.lunarDay {
+			t.Errorf("%04d-%02d-%02d gives lunar month %d (leap %v) day %d, want month %d (leap %v) day %d",
+				c.year, c.month, c.day, l.Month, l.LeapMonth, l.Day, c.lunarMonth, c.leap, c.lunarDay)
+		}
+	}
+}
+
+// TestLunarRoundTrip : Solar to lunar to solar gives the same date, across leap months
+func TestLunarRoundTrip(t *testing.T) {
+	for _, r := range [][2]string{{"2020-03-01", "2020-08-31"}, {"2023-02-01", "2023-06-30"}, {"1984-10-01", "1985-02-28"}} {
+		from, _ := time.Parse("2006-01-02", r[0])
+		to, _ := time.Parse("2006-01-02", r[1])
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			l := lunarOf(d.Year(), int(d.Month()), d.Day())
+			year, month, day, err := LunarToSolar(l.Year, l.Month, l.LeapMonth, l.Day)
+			if err != nil {
+				t.Errorf("%s: %s", d.Format("2006-01-02"), err)
+				continue
+			}
+
+			if year != d.Year() || month != int(d.Month()) || day != d.Day() {
+				t.Errorf("%s gives lunar %d-%d (leap %v)-%d, back to %04d-%02d-%02d",
+					d.Format("2006-01-02"), l.Year, l.Month, l.LeapMonth, l.Day, year, month, day)
+			}
+		}
+	}
+}
+
+// TestLunarToSolarInvalid : Leap flag on month without leap, and days beyond month length
+func TestLunarToSolarInvalid(t *testing.T) {
+	if _, _, _, err := LunarToSolar(2020, 5, true, 1); err == nil {
+		t.Error("Leap 5th month of 2020 accepted")
+	}
+
+	if _, _, _, err := LunarToSolar(2020, 4, true, 30); err == nil {
+		t.Error("Day 30 of 29-day leap 4th month of 2020 accepted")
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -31,7 +31,6 @@
 package calendar
 
 import (
-	"math"
 	"time"
 )
 
@@ -295,14 +294,14 @@ var (
 		},
 		{
 			-946298205, -945026178, -943750366, -942468991, -941178972, -939879386,
-			-938568333, -937246152, -935912629, -934569422, -933221759, -931865017,
-			-930505916, -929147152, -927792500, -926443878, -925104636, -923775258,
+			-938568333, -937246152, -935912629, -934569422, -933218159, -931861417,
+			-930502316, -929143552, -927788900, -926440278, -925101036, -923771658,
 			-922454249, -921147629, -919852379, -918565845, -917287307, -916013096,
 		},
 		{
-			-914741741, -913469160, -912193789, -910911790, -909622164, -908325544,
-			-907014867, -905692135, -904358969, -903015393, -901664408, -900307568,
-			-898948578, -897589998, -896235215, -894886957, -893547339, -892218405,
+			-914741741, -913469160, -912193789, -910911790, -909622164, -908321944,
+			-907011267, -905688535, -904355369, -903011793, -901660808, -900303968,
+			-898944978, -897586398, -896231615, -894883357, -893543739, -892214805,
 			-890896875, -889590740, -888294928, -887008906, -885729815, -884456125,
 		},
 		{
@@ -571,38 +570,38 @@ var (
 		},
 		{
 			505322898, 506594788, 507870476, 509151464, 510441139, 511740170,
-			513050774, 514372332, 515701836, 517044472, 518395457, 519751793,
-			521110840, 522469457, 523824329, 525173137, 526512864, 527846318,
+			513050774, 514372332, 515705436, 517048072, 518399057, 519755393,
+			521114440, 522473057, 523827929, 525176737, 526516464, 527846318,
 			529164389, 530471632, 531767543, 533054629, 534333623, 535608097,
 		},
 		{
 			536879554, 538152000, 539427078, 540708575, 541997595, 543297097,
-			544607024, 545925428, 547257908, 548600972, 549951507, 551308214,
-			552667092, 554025935, 555380928, 556729762, 558069818, 559403087,
+			544607024, 545929028, 547261508, 548604572, 549955107, 551311814,
+			552670692, 554029535, 555384528, 556733362, 558073418, 559403087,
 			560721554, 562028429, 563324721, 564611346, 565890716, 567164738,
 		},
 		{
 			568436597, 569708650, 570984167, 572265310, 573554799, 574853924,
-			576164354, 577482297, 578815315, 580157812, 581508902, 582865000,
-			584224382, 585582675, 586938028, 588286455, 589626705, 590959747,
+			576164354, 577485897, 578818915, 580161412, 581512502, 582868600,
+			584227982, 585586275, 586941628, 588290055, 589630305, 590959747,
 			592278287, 593585068, 594881358, 596167946, 597447297, 598721303,
 		},
 		{
 			599993186, 601265249, 602540861, 603822063, 605111682, 606410931,
-			607721429, 609039572, 610372471, 611715248, 613065949, 614422415,
-			615781199, 617139959, 618494661, 619843600, 621183262, 622516806,
+			607721429, 609043172, 610376071, 611718848, 613069549, 614426015,
+			615784799, 617143559, 618498261, 619847200, 621186862, 622516806,
 			623834869, 625142137, 626438038, 627725100, 629004079, 630278542,
 		},
 		{
 			631550015, 632822510, 634097652, 635379251, 636668365, 637967963,
-			639277980, 640596395, 641928926, 643271840, 644622374, 645978761,
-			647337622, 648696081, 650051119, 651399633, 652739829, 654072910,
+			639277980, 640599995, 641932526, 643275440, 644625974, 645982361,
+			647341222, 648699681, 650054719, 651403233, 652743429, 654072910,
 			655391607, 656698417, 657994988, 659281594, 660561227, 661835195,
 		},
 		{
 			663107263, 664379199, 665654878, 666935870, 668225505, 669524486,
-			670835053, 672152876, 673485987, 674828387, 676179467, 677535492,
-			678894745, 680253035, 681608203, 682956739, 684296809, 685630056,
+			670835053, 672156476, 673489587, 674831987, 676183067, 677539092,
+			678898345, 680256635, 681611803, 682960339, 684300409, 685630056,
 			686948441, 688255486, 689551650, 690838524, 692117743, 693392003,
 		},
 		{
@@ -805,13 +804,6 @@ var (
 		},
 	}
 
-	normalSolartermDelta = []float64{
-		4.25, 19.095, 6.006, 21.031, 5.2, 20.494,
-		5.919, 21.45, 6.089, 22.285, 7.516, 23.24,
-		7.925, 23.54, 8.043, 23.432, 8.708, 23.828,
-		7.828, 22.72, 7.54, 22.27, 5.758, 20.48,
-	}
-
 	solartermAliases = []string{
 		"小寒", "大寒", "立春", "雨水", "惊蛰", "春分",
 		"清明", "谷雨", "立夏", "小满", "芒种", "夏至",
@@ -827,21 +819,8 @@ func GetSolarterms(year int) []time.Time {
 		return solarterms[year-1904]
 	}
 
-	loc, _ := time.LoadLocation("Asia/Shanghai")
-	y := float64(year % 100)
-	ret := make([]time.Time, 24)
-	for s := 0; s < 24; s++ {
-		fix := y / 4
-		if s < 4 {
-			fix = (y - 1) / 4
-		}
-
-		month := time.Month(int(math.Ceil(float64(s)/2)) + 1)
-		day := int(y*0.2422 + normalSolartermDelta[s] - fix)
-		ret[s] = time.Date(year, month, day, 0, 0, 0, 0, loc)
-	}
-
-	return ret
+	// Out of table
+	return ComputeSolarterms(year)
 }
 
 // init : Create solarterm table
@@ -0,0 +1,60 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file solar_test.go
+ * @package calendar
+ * @since 10/17/2026
+ */
+
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+// solartermTolerance : Maximum difference between table and computed solarterms
+const solartermTolerance = time.Minute
+
+// TestSolartermTable : Table solarterms agree with computed ones
+func TestSolartermTable(t *testing.T) {
+	for y := 1904; y <= 2024; y++ {
+		computed := ComputeSolarterms(y)
+		for i, ts := range solartermTimestamps[y-1904] {
+			d := time.Unix(ts, 0).Sub(computed[i])
+			if d > solartermTolerance || d < -solartermTolerance {
+				t.Errorf("Solarterm %s of %d differs from computed by %s", solartermAliases[i], y, d)
+			}
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -32,12 +32,17 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
+	"yixuan_naming/calendar"
 	"yixuan_naming/common"
+	"yixuan_naming/list"
 	"yixuan_naming/name"
+	"yixuan_naming/place"
 	"yixuan_naming/texts"
 	"yixuan_naming/utils"
 
@@ -86,8 +91,6 @@ func nameRank(ctx *fasthttp.RequestCtx) {
 		givenName       []byte
 		givenNameRunes  []rune
 		birthTime       int64
-		longitude       float64
-		latitude        float64
 		language        []byte
 		languageCode    int
 	)
@@ -134,29 +137,89 @@ func nameRank(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
-	b := args.Peek("birth")
-	if b != nil {
-		birthTime, _ = strconv.ParseInt(string(b), 10, 64)
+	// Generation character as middle name, or appended to given name as its last character
+	poem, ok := generationPoem(ctx, familyNameRunes)
+	if !ok {
+		return
 	}
 
-	longitude = args.GetUfloatOrZero("longitude")
-	latitude = args.GetUfloatOrZero("latitude")
+	if len(poem) > 0 {
+		var (
+			suffix []rune
+			err    error
+		)
+
+		middleNameRunes, suffix, err = name.PinGeneration(poem, args.GetUintOrZero("generation"), args.GetUintOrZero("generation_position"), middleNameRunes, nil)
+		if err != nil {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			ctx.SetUserValue("_envelope_code", 10400)
+			ctx.SetUserValue("_envelope_message", err.Error())
+
+			return
+		}
+
+		givenNameRunes = append(givenNameRunes, suffix...)
+	}
+
+	loc, _, ok := birthLocation(ctx, "")
+	if !ok {
+		return
+	}
+
+	birthTime, ok = birthTimestamp(ctx, "", &loc)
+	if !ok {
+		return
+	}
+
+	ziHour, ok := ziHourConvention(ctx)
+	if !ok {
+		return
+	}
+
+	fromYear, years, ok := annualRange(ctx)
+	if !ok {
+		return
+	}
+
+	school, ok := strokeSchool(ctx)
+	if !ok {
+		return
+	}
+
+	gender := args.GetUintOrZero("gender")
+
 	language = args.Peek("lang")
 	languageCode = texts.AssertLanguage(string(language))
 
 	r := ctx.UserValue("_g").(*common.GlobalRuntime)
-	r.Logger.Printf("Name rank from %s with name <%v.%v.%v>, birth timestamp <%d>, location <%f:%f>, language <%d>",
+	r.Logger.Printf("Name rank from %s with name <%v.%v.%v>, birth timestamp <%d>, location <%f:%f %s>, language <%d>",
 		ctx.RemoteIP().String(),
 		familyNameRunes,
 		middleNameRunes,
 		givenNameRunes,
 		birthTime,
-		latitude,
-		longitude,
+		loc.Latitude,
+		loc.Longitude,
+		loc.Zone,
 		languageCode)
 	n := name.NewNameRunes(familyNameRunes, middleNameRunes, givenNameRunes)
+	pinyin := args.Peek("pinyin")
+	if len(pinyin) > 0 {
+		n.OverrideReadings(strings.Split(string(pinyin), ","))
+	}
+
+	n.SetStrokeSchool(school)
 	n.Normalize()
-	ret, _ := name.Rank(languageCode, n, birthTime, utils.Location{Latitude: latitude, Longitude: longitude})
+	if err := n.ReadingsError(); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", err.Error())
+
+		return
+	}
+
+	ret, _ := name.Rank(languageCode, n, birthTime, loc, ziHour)
+	ret.CalculateLuck(gender, fromYear, years)
 
 	ctx.SetUserValue("_envelope_data", ret)
 
@@ -172,15 +235,17 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 		prefixNameRunes []rune
 		suffixName      []byte
 		suffixNameRunes []rune
+		poem            []rune
+		generation      int
+		position        int
 		birthTime       int64
-		longitude       float64
-		latitude        float64
 		givenNameLength int
 		gender          int
 		queryNums       int
 		maxRank         int
 		minRank         int
 		characterLevel  int
+		minPhonetics    int
 		language        []byte
 		languageCode    int
 	)
@@ -227,13 +292,14 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 		}
 	}
 
-	b := args.Peek("birth")
-	if b != nil {
-		birthTime, _ = strconv.ParseInt(string(b), 10, 64)
+	poem, ok := generationPoem(ctx, familyNameRunes)
+	if !ok {
+		return
 	}
 
-	longitude = args.GetUfloatOrZero("longitude")
-	latitude = args.GetUfloatOrZero("latitude")
+	generation = args.GetUintOrZero("generation")
+	position = args.GetUintOrZero("generation_position")
+
 	givenNameLength = args.GetUintOrZero("length")
 	gender = args.GetUintOrZero("gender")
 	if gender != utils.GenderFemale && gender != utils.GenderMale {
@@ -246,10 +312,27 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 	language = args.Peek("lang")
 	languageCode = texts.AssertLanguage(string(language))
 	characterLevel = args.GetUintOrZero("character_level")
+	minPhonetics = args.GetUintOrZero("min_phonetics")
+
+	loc, specified, ok := birthLocation(ctx, "")
+	if !ok {
+		return
+	}
+
+	defaultLocation(&loc, specified)
+	birthTime, ok = birthTimestamp(ctx, "", &loc)
+	if !ok {
+		return
+	}
+
+	ziHour, ok := ziHourConvention(ctx)
+	if !ok {
+		return
+	}
 
-	if 0 == longitude && 0 == latitude {
-		longitude = 120.0
-		latitude = 45.0
+	school, ok := strokeSchool(ctx)
+	if !ok {
+		return
 	}
 
 	r := ctx.UserValue("_g").(*common.GlobalRuntime)
@@ -265,17 +348,37 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 		MaxRank:         maxRank,
 		MinRank:         minRank,
 		CharacterLevel:  characterLevel,
+
+		GenerationPoem:     poem,
+		GenerationIndex:    generation,
+		GenerationPosition: position,
+
+		MinPhonetics:   minPhonetics,
+		ZiHour:         ziHour,
+		ExcludeOminous: args.GetBool("exclude_ominous"),
+		StrokeSchool:   school,
+	}
+
+	err := conditions.ApplyGeneration()
+	if err != nil {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", err.Error())
+
+		return
 	}
 
 	conditions.Traditionalize()
-	r.Logger.Printf("Name kirsen from %s with family name <%v>, prefix <%v> and suffix <%v>, birth timestamp <%d>, location <%f:%f>, given name length <%d>, gender <%d>, character level <%d>, query numbers <%d>, level between <%d, %d> language <%d>",
+	r.Logger.Printf("Name kirsen from %s with family name <%v>, middle <%v>, prefix <%v> and suffix <%v>, birth timestamp <%d>, location <%f:%f %s>, given name length <%d>, gender <%d>, character level <%d>, query numbers <%d>, level between <%d, %d> language <%d>",
 		ctx.RemoteIP().String(),
 		conditions.FamilyNameRunes,
+		conditions.MiddleNameRunes,
 		conditions.PrefixNameRunes,
 		conditions.SuffixNameRunes,
 		birthTime,
-		latitude,
-		longitude,
+		loc.Latitude,
+		loc.Longitude,
+		loc.Zone,
 		givenNameLength,
 		gender,
 		characterLevel,
@@ -284,12 +387,493 @@ func nameKirsen(ctx *fasthttp.RequestCtx) {
 		maxRank,
 		languageCode)
 
-	ret, _ := name.Kirsen(languageCode, conditions, birthTime, utils.Location{Latitude: latitude, Longitude: longitude})
+	ret, err := name.Kirsen(languageCode, conditions, birthTime, loc)
+	if err != nil {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", err.Error())
+
+		return
+	}
+
 	ctx.SetUserValue("_envelope_data", ret)
 
 	return
 }
 
+// parseCoordinate : Signed coordinate argument in [-limit, limit], with presence
+func parseCoordinate(ctx *fasthttp.RequestCtx, key string, limit float64) (float64, bool, bool) {
+	v := ctx.QueryArgs().Peek(key)
+	if len(v) == 0 {
+		return 0, false, true
+	}
+
+	f, err := strconv.ParseFloat(string(v), 64)
+	if err != nil || math.IsNaN(f) || f < -limit || f > limit {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", fmt.Sprintf("Invalid %s, must be a number between %g and %g", key, -limit, limit))
+
+		return 0, false, false
+	}
+
+	return f, true, true
+}
+
+// birthLocation : Location of birth by coordinates or birth place name, explicit coordinates prefered.
+// Argument names prefixed for multiple persons. Returns location, whether any location given and whether arguments valid
+func birthLocation(ctx *fasthttp.RequestCtx, prefix string) (utils.Location, bool, bool) {
+	var (
+		loc                       utils.Location
+		hasLatitude, hasLongitude bool
+		ok                        bool
+	)
+
+	loc.Latitude, hasLatitude, ok = parseCoordinate(ctx, prefix+"latitude", 90)
+	if !ok {
+		return loc, false, false
+	}
+
+	loc.Longitude, hasLongitude, ok = parseCoordinate(ctx, prefix+"longitude", 180)
+	if !ok {
+		return loc, false, false
+	}
+
+	birthPlace := ctx.QueryArgs().Peek(prefix + "birth_place")
+	if len(birthPlace) == 0 {
+		return loc, hasLatitude || hasLongitude, true
+	}
+
+	p := place.Query(string(birthPlace))
+	if p == nil {
+		ctx.SetStatusCode(fasthttp.StatusNotFound)
+		ctx.SetUserValue("_envelope_code", 10404)
+		ctx.SetUserValue("_envelope_message", "Place does not exist")
+
+		return loc, false, false
+	}
+
+	if !hasLatitude && !hasLongitude {
+		return p.Location(), true, true
+	}
+
+	loc.Zone = p.Zone
+
+	return loc, true, true
+}
+
+// generationPoem : Generation poem of request (poem), or poem of family from config (Generation_Poems)
+// if generation index given without poem
+func generationPoem(ctx *fasthttp.RequestCtx, family []rune) ([]rune, bool) {
+	args := ctx.QueryArgs()
+	if poem := args.Peek("poem"); len(poem) > 0 {
+		return []rune(string(poem)), true
+	}
+
+	if !args.Has("generation") {
+		return nil, true
+	}
+
+	r := ctx.UserValue("_g").(*common.GlobalRuntime)
+	poem := r.Config.GetStringMapString("Generation_Poems")[string(family)]
+	if poem == "" {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", fmt.Sprintf("No generation poem of family <%s>, poem required", string(family)))
+
+		return nil, false
+	}
+
+	return []rune(poem), true
+}
+
+// defaultLocation : Default coordinates of birth location not specified
+func defaultLocation(loc *utils.Location, specified bool) {
+	if !specified {
+		loc.Longitude = DefaultLongitude
+		loc.Latitude = DefaultLatitude
+	}
+}
+
+// birthTimestamp : Birth timestamp by unix timestamp (birth), civil time (birth_local) or lunar date with ShiChen
+// (lunar_year, lunar_month, leap, lunar_day, shichen) in time zone (tz), zone of location assigned.
+// ShiChen is already real-sun time, so lunar input is pinned to real-sun time of location.
+// Argument names prefixed for multiple persons
+func birthTimestamp(ctx *fasthttp.RequestCtx, prefix string, loc *utils.Location) (int64, bool) {
+	var (
+		args      = ctx.QueryArgs()
+		local     = args.Peek(prefix + "birth_local")
+		tz        = string(args.Peek(prefix + "tz"))
+		birthTime int64
+		err       error
+	)
+
+	_invalid := func(err error) (int64, bool) {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", err.Error())
+
+		return 0, false
+	}
+
+	if tz != "" {
+		_, err = time.LoadLocation(tz)
+		if err != nil {
+			return _invalid(fmt.Errorf("Unknown time zone <%s>", tz))
+		}
+
+		loc.Zone = tz
+	}
+
+	if len(local) == 0 && args.Has(prefix+"lunar_year") {
+		// Lunar date & ShiChen
+		var lunarArgs = make(map[string]int)
+		for _, k := range []string{"lunar_year", "lunar_month", "lunar_day", "shichen"} {
+			lunarArgs[k], err = args.GetUint(prefix + k)
+			if err != nil {
+				return _invalid(fmt.Errorf("Invalid or missing %s%s", prefix, k))
+			}
+		}
+
+		year, month, day, err := calendar.LunarToSolar(lunarArgs["lunar_year"], lunarArgs["lunar_month"], args.GetBool(prefix+"leap"), lunarArgs["lunar_day"])
+		if err != nil {
+			return _invalid(err)
+		}
+
+		hour, err := calendar.ShichenHour(lunarArgs["shichen"])
+		if err != nil {
+			return _invalid(err)
+		}
+
+		if loc.Zone == "" {
+			loc.Zone = calendar.DefaultZone
+		}
+
+		return calendar.RealSunTimestamp(year, month, day, hour, *loc), true
+	}
+
+	if len(local) == 0 {
+		birthTime, _ = strconv.ParseInt(string(args.Peek(prefix+"birth")), 10, 64)
+
+		return birthTime, true
+	}
+
+	birthTime, err = calendar.ParseCivil(string(local), loc.Zone, args.GetBool(prefix+"fold"))
+	if err != nil {
+		return _invalid(err)
+	}
+
+	if loc.Zone == "" {
+		loc.Zone = calendar.DefaultZone
+	}
+
+	return birthTime, true
+}
+
+func nameChart(ctx *fasthttp.RequestCtx) {
+	args := ctx.QueryArgs()
+	gender := args.GetUintOrZero("gender")
+	if gender != utils.GenderMale && gender != utils.GenderFemale {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", "Invalid gender, must be 1 (male) or 2 (female)")
+
+		return
+	}
+
+	loc, specified, ok := birthLocation(ctx, "")
+	if !ok {
+		return
+	}
+
+	defaultLocation(&loc, specified)
+	birthTime, ok := birthTimestamp(ctx, "", &loc)
+	if !ok {
+		return
+	}
+
+	ziHour, ok := ziHourConvention(ctx)
+	if !ok {
+		return
+	}
+
+	fromYear, years, ok := annualRange(ctx)
+	if !ok {
+		return
+	}
+
+	languageCode := texts.AssertLanguage(string(args.Peek("lang")))
+
+	r := ctx.UserValue("_g").(*common.GlobalRuntime)
+	r.Logger.Printf("Name chart from %s with birth timestamp <%d>, location <%f:%f %s>, gender <%d>, annual years <%d+%d>, language <%d>",
+		ctx.RemoteIP().String(),
+		birthTime,
+		loc.Latitude,
+		loc.Longitude,
+		loc.Zone,
+		gender,
+		fromYear,
+		years,
+		languageCode)
+
+	ctx.SetUserValue("_envelope_data", name.Chart(languageCode, birthTime, loc, ziHour, gender, fromYear, years))
+
+	return
+}
+
+// nameGrid : Reverse five-grid search, target grids as exact value (tian_ge) or range (tian_ge_min, tian_ge_max)
+func nameGrid(ctx *fasthttp.RequestCtx) {
+	var (
+		args       = ctx.QueryArgs()
+		conditions = &name.GridSearchConditions{}
+		ok         bool
+	)
+
+	conditions.FamilyNameRunes = []rune(string(args.Peek("family")))
+	for _, g := range []struct {
+		key string
+		r   *name.GridRange
+	}{
+		{"tian_ge", &conditions.TianGe},
+		{"ren_ge", &conditions.RenGe},
+		{"di_ge", &conditions.DiGe},
+		{"wai_ge", &conditions.WaiGe},
+		{"zong_ge", &conditions.ZongGe},
+	} {
+		*g.r, ok = gridRange(ctx, g.key)
+		if !ok {
+			return
+		}
+	}
+
+	conditions.StrokeSchool, ok = strokeSchool(ctx)
+	if !ok {
+		return
+	}
+
+	conditions.GivenNameLength = args.GetUintOrZero("length")
+	conditions.MinThreeElementRank = args.GetUintOrZero("min_three_element")
+	conditions.CharacterLevel = args.GetUintOrZero("character_level")
+	conditions.QueryNums = args.GetUintOrZero("nums")
+	conditions.Gender = args.GetUintOrZero("gender")
+	if conditions.Gender != utils.GenderFemale && conditions.Gender != utils.GenderMale {
+		conditions.Gender = utils.GenderUnknown
+	}
+
+	languageCode := texts.AssertLanguage(string(args.Peek("lang")))
+
+	r := ctx.UserValue("_g").(*common.GlobalRuntime)
+	r.Logger.Printf("Name grid search from %s with family name <%v>, given name length <%d>, stroke school <%d>, language <%d>",
+		ctx.RemoteIP().String(),
+		conditions.FamilyNameRunes,
+		conditions.GivenNameLength,
+		conditions.StrokeSchool,
+		languageCode)
+
+	ret, err := name.GridSearch(languageCode, conditions)
+	if err != nil {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", err.Error())
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", ret)
+
+	return
+}
+
+// nameFamily : Compatibility of child (m0_*) with family members (m1_* ...), arguments of each member
+// prefixed by "m<index>_" with role (m<index>_role) and birth arguments as chart
+func nameFamily(ctx *fasthttp.RequestCtx) {
+	var (
+		args    = ctx.QueryArgs()
+		members []*name.FamilyMember
+	)
+
+	for i := 0; i < name.MaxFamilyMembers; i++ {
+		prefix := fmt.Sprintf("m%d_", i)
+		if !args.Has(prefix+"birth") && !args.Has(prefix+"birth_local") && !args.Has(prefix+"lunar_year") {
+			break
+		}
+
+		loc, specified, ok := birthLocation(ctx, prefix)
+		if !ok {
+			return
+		}
+
+		defaultLocation(&loc, specified)
+		birthTime, ok := birthTimestamp(ctx, prefix, &loc)
+		if !ok {
+			return
+		}
+
+		role := name.FamilySibling
+		if i == 0 {
+			role = name.FamilyChild
+		} else if args.Has(prefix + "role") {
+			role = args.GetUintOrZero(prefix + "role")
+		}
+
+		members = append(members, &name.FamilyMember{
+			Role:      role,
+			BirthTime: birthTime,
+			Location:  loc,
+		})
+	}
+
+	ziHour, ok := ziHourConvention(ctx)
+	if !ok {
+		return
+	}
+
+	languageCode := texts.AssertLanguage(string(args.Peek("lang")))
+
+	r := ctx.UserValue("_g").(*common.GlobalRuntime)
+	r.Logger.Printf("Name family from %s with %d members, language <%d>",
+		ctx.RemoteIP().String(),
+		len(members),
+		languageCode)
+
+	ret, err := name.Family(languageCode, members, ziHour)
+	if err != nil {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", err.Error())
+
+		return
+	}
+
+	ctx.SetUserValue("_envelope_data", ret)
+
+	return
+}
+
+// annualRange : First year (from_year, current year by default) and number (years) of annual pillars
+func annualRange(ctx *fasthttp.RequestCtx) (int, int, bool) {
+	var (
+		args     = ctx.QueryArgs()
+		fromYear = time.Now().Year()
+		years    = name.DefaultAnnualPillars
+		err      error
+	)
+
+	if args.Has("from_year") {
+		fromYear, err = strconv.Atoi(string(args.Peek("from_year")))
+		if err != nil || fromYear < 1 || fromYear > 9999 {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			ctx.SetUserValue("_envelope_code", 10400)
+			ctx.SetUserValue("_envelope_message", "Invalid from_year")
+
+			return 0, 0, false
+		}
+	}
+
+	if args.Has("years") {
+		years, err = strconv.Atoi(string(args.Peek("years")))
+		if err != nil || years < 1 || years > name.MaxAnnualPillars {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			ctx.SetUserValue("_envelope_code", 10400)
+			ctx.SetUserValue("_envelope_message", fmt.Sprintf("Invalid years, must be between 1 and %d", name.MaxAnnualPillars))
+
+			return 0, 0, false
+		}
+	}
+
+	return fromYear, years, true
+}
+
+// ziHourConvention : Zi hour convention of day pillar (zi_hour), configured one by default
+func ziHourConvention(ctx *fasthttp.RequestCtx) (int, bool) {
+	r := ctx.UserValue("_g").(*common.GlobalRuntime)
+	ziHour := r.Config.GetInt("Zi_Hour_Convention")
+	if ctx.QueryArgs().Has("zi_hour") {
+		ziHour = ctx.QueryArgs().GetUintOrZero("zi_hour")
+		if ziHour != calendar.ZiHourChangeDay && ziHour != calendar.ZiHourLateZi {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			ctx.SetUserValue("_envelope_code", 10400)
+			ctx.SetUserValue("_envelope_message", "Invalid zi_hour, must be 0 or 1")
+
+			return 0, false
+		}
+	}
+
+	return ziHour, true
+}
+
+// parseGridNumber : Non-negative grid number of key, 0 if not given
+func parseGridNumber(ctx *fasthttp.RequestCtx, key string) (int, bool) {
+	v := ctx.QueryArgs().Peek(key)
+	if len(v) == 0 {
+		return 0, true
+	}
+
+	n, err := strconv.Atoi(string(v))
+	if err != nil || n < 0 {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", fmt.Sprintf("Invalid %s, must be a non-negative integer", key))
+
+		return 0, false
+	}
+
+	return n, true
+}
+
+// gridRange : Exact grid number of key, or range of key_min and key_max
+func gridRange(ctx *fasthttp.RequestCtx, key string) (name.GridRange, bool) {
+	var (
+		ret name.GridRange
+		ok  bool
+	)
+
+	if ctx.QueryArgs().Has(key) {
+		if ret.Min, ok = parseGridNumber(ctx, key); !ok {
+			return ret, false
+		}
+
+		ret.Max = ret.Min
+	} else {
+		if ret.Min, ok = parseGridNumber(ctx, key+"_min"); !ok {
+			return ret, false
+		}
+
+		if ret.Max, ok = parseGridNumber(ctx, key+"_max"); !ok {
+			return ret, false
+		}
+	}
+
+	if !ret.Valid() {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetUserValue("_envelope_code", 10400)
+		ctx.SetUserValue("_envelope_message", fmt.Sprintf("Invalid range of %s", key))
+
+		return ret, false
+	}
+
+	return ret, true
+}
+
+// strokeSchool : Stroke counting school from config, overridden by stroke_school
+func strokeSchool(ctx *fasthttp.RequestCtx) (int, bool) {
+	r := ctx.UserValue("_g").(*common.GlobalRuntime)
+	school, _ := list.AssertStrokeSchool(r.Config.GetString("Stroke_School"))
+	if ctx.QueryArgs().Has("stroke_school") {
+		var ok bool
+		school, ok = list.AssertStrokeSchool(string(ctx.QueryArgs().Peek("stroke_school")))
+		if !ok {
+			ctx.SetStatusCode(fasthttp.StatusBadRequest)
+			ctx.SetUserValue("_envelope_code", 10400)
+			ctx.SetUserValue("_envelope_message", "Invalid stroke_school, must be kumazaki, kangxi or simplified")
+
+			return 0, false
+		}
+	}
+
+	return school, true
+}
+
 // HTTP CORS Options request
 func cors(ctx *fasthttp.RequestCtx) {
 	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
@@ -333,10 +917,14 @@ func svc(s *common.HTTPServer) {
 	s.Router.GET("/api/unihan/:mode/:input", f(apiUnihan, "none", s))
 	s.Router.GET("/api/stroke/:mode/:input", f(apiStroke, "none", s))
 	s.Router.GET("/api/traditional/:mode/:input", f(apiTraditional, "none", s))
+	s.Router.GET("/api/place", f(apiPlace, "none", s))
 
 	// Logics
 	s.Router.GET("/name/rank", f(nameRank, "none", s))
 	s.Router.GET("/name/kirsen", f(nameKirsen, "none", s))
+	s.Router.GET("/name/chart", f(nameChart, "none", s))
+	s.Router.GET("/name/family", f(nameFamily, "none", s))
+	s.Router.GET("/name/grid", f(nameGrid, "none", s))
 
 	// Tasks
 	s.Router.GET("/task/common_chars_length", taskCommonChars)
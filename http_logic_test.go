@@ -0,0 +1,94 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file http_logic_test.go
+ * @package main
+ * @since 10/17/2026
+ */
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+
+	"yixuan_naming/calendar"
+)
+
+// TestBirthTimestampShichen : ShiChen of lunar input pins hour and day pillars, regardless of location
+func TestBirthTimestampShichen(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+	}{
+		{"urumqi", "longitude=87.6&latitude=43.8&tz=Asia/Shanghai"},
+		{"kashgar", "longitude=75.99&latitude=39.47"},
+		{"no location", ""},
+	}
+
+	for _, c := range cases {
+		for shichen := 0; shichen < 12; shichen++ {
+			var ctx fasthttp.RequestCtx
+			ctx.Request.SetRequestURI(fmt.Sprintf("/name/rank?lunar_year=1990&lunar_month=5&leap=1&lunar_day=1&shichen=%d&%s", shichen, c.query))
+
+			loc, _, ok := birthLocation(&ctx, "")
+			if !ok {
+				t.Fatalf("%s: invalid location", c.name)
+			}
+
+			ts, ok := birthTimestamp(&ctx, "", &loc)
+			if !ok {
+				t.Fatalf("%s: invalid birth time: %v", c.name, ctx.UserValue("_envelope_message"))
+			}
+
+			year, month, day, _ := calendar.LunarToSolar(1990, 5, true, 1)
+			hour, _ := calendar.ShichenHour(shichen)
+			cal := calendar.New(ts, loc, calendar.ZiHourChangeDay)
+			if cal.RealTime.Year != year || cal.RealTime.Month != month || cal.RealTime.Day != day || cal.RealTime.Hour != hour || cal.RealTime.Minute != 0 {
+				t.Errorf("%s: shichen %d gives real-sun time %+v, want %04d-%02d-%02d %02d:00", c.name, shichen, cal.RealTime, year, month, day, hour)
+			}
+
+			if cal.Ganzhi.Hour.DiZhi != shichen {
+				t.Errorf("%s: shichen %d gives hour branch %d", c.name, shichen, cal.Ganzhi.Hour.DiZhi)
+			}
+
+			// Noon of the same real-sun day
+			noon := calendar.New(calendar.RealSunTimestamp(year, month, day, 12, loc), loc, calendar.ZiHourChangeDay)
+			if cal.Ganzhi.Day != noon.Ganzhi.Day {
+				t.Errorf("%s: shichen %d gives day pillar %+v, want %+v", c.name, shichen, cal.Ganzhi.Day, noon.Ganzhi.Day)
+			}
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
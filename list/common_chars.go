@@ -44,10 +44,13 @@ const (
 )
 
 var (
-	commonCharactersL1                  map[rune]int32
-	commonCharactersL2                  map[rune]int32
-	commonCharactersL1Traditional       map[rune]int32
-	commonCharactersL2Traditional       map[rune]int32
+	commonCharactersL1            map[rune]int32
+	commonCharactersL2            map[rune]int32
+	commonCharactersL1Traditional map[rune]int32
+	commonCharactersL2Traditional map[rune]int32
+	commonCharactersStroke        [][][][]rune
+
+	// Legacy buckets by preferred stroke of each form, served by GetCommonL*ByStroke*
 	commonCharactersStrokeL1            [][]rune
 	commonCharactersStrokeL2            [][]rune
 	commonCharactersStrokeL1Traditional [][]rune
@@ -138,67 +141,93 @@ func CountCommonL2() int {
 	return len(commonCharactersL2)
 }
 
-// PrepareCommonCharacters : Traditionalize common list and count strokes
+// PrepareCommonCharacters : Traditionalize common list and count strokes of each school
 func PrepareCommonCharacters() int {
+	var t1, t2 int
+
+	commonCharactersL1Traditional, t1 = traditionalizeCommon(commonCharactersL1)
+	commonCharactersL2Traditional, t2 = traditionalizeCommon(commonCharactersL2)
+	for school := 0; school < StrokeSchools; school++ {
+		commonCharactersStroke[school] = [][][]rune{
+			strokeBuckets(school, commonCharactersL1),
+			strokeBuckets(school, commonCharactersL2),
+		}
+	}
+
+	commonCharactersStrokeL1 = preferStrokeBuckets(commonCharactersL1)
+	commonCharactersStrokeL2 = preferStrokeBuckets(commonCharactersL2)
+	commonCharactersStrokeL1Traditional = preferStrokeBuckets(commonCharactersL1Traditional)
+	commonCharactersStrokeL2Traditional = preferStrokeBuckets(commonCharactersL2Traditional)
+
+	return t1 + t2
+}
+
+// traditionalizeCommon : Traditional forms of common list
+func traditionalizeCommon(m map[rune]int32) (map[rune]int32, int) {
 	var (
-		r, rt  rune
-		ct     int32
-		c      *unihan.HanCharacter
-		stroke int
-		total  int
+		ret   = make(map[rune]int32)
+		total int
 	)
 
-	commonCharactersL1Traditional = make(map[rune]int32)
-	for r, ct = range commonCharactersL1 {
-		c, _ = unihan.Query(r)
-		if c != nil {
-			stroke = c.QueryStrokePrefer()
-			if stroke <= MaxStroke {
-				commonCharactersStrokeL1[stroke] = append(commonCharactersStrokeL1[stroke], r)
-			}
+	for r, ct := range m {
+		c, _ := unihan.Query(r)
+		if c == nil {
+			continue
+		}
 
-			rt, _ = c.QueryTraditionalLazy()
-			if rt > 0 {
-				c, _ = unihan.Query(rt)
-				if c != nil {
-					commonCharactersL1Traditional[rt] = ct
-					stroke = c.QueryStrokePrefer()
-					if stroke <= MaxStroke {
-						commonCharactersStrokeL1Traditional[stroke] = append(commonCharactersStrokeL1Traditional[stroke], rt)
-					}
-
-					total++
-				}
+		rt, _ := c.QueryTraditionalLazy()
+		if rt > 0 {
+			c, _ = unihan.Query(rt)
+			if c != nil {
+				ret[rt] = ct
+				total++
 			}
 		}
 	}
 
-	commonCharactersL2Traditional = make(map[rune]int32)
-	for r, ct = range commonCharactersL2 {
-		c, _ = unihan.Query(r)
-		if c != nil {
-			stroke = c.QueryStrokePrefer()
-			if stroke <= MaxStroke {
-				commonCharactersStrokeL2[stroke] = append(commonCharactersStrokeL2[stroke], r)
-			}
+	return ret, total
+}
 
-			rt, _ = c.QueryTraditionalLazy()
-			if rt > 0 {
-				c, _ = unihan.Query(rt)
-				if c != nil {
-					commonCharactersL2Traditional[rt] = ct
-					stroke = c.QueryStrokePrefer()
-					if stroke <= MaxStroke {
-						commonCharactersStrokeL2Traditional[stroke] = append(commonCharactersStrokeL2Traditional[stroke], rt)
-					}
-
-					total++
-				}
-			}
+// strokeBuckets : Common characters in counted form of school, by strokes
+func strokeBuckets(school int, m map[rune]int32) [][]rune {
+	var (
+		ret     = make([][]rune, MaxStroke+1)
+		counter = GetStrokeCounter(school)
+		seen    = make(map[rune]bool)
+	)
+
+	for r := range m {
+		c := QuerySchoolForm(school, r)
+		if c == nil || seen[c.Unicode] {
+			continue
+		}
+
+		seen[c.Unicode] = true
+		stroke := counter.Count(c)
+		if stroke > 0 && stroke <= MaxStroke {
+			ret[stroke] = append(ret[stroke], c.Unicode)
+		}
+	}
+
+	return ret
+}
+
+// preferStrokeBuckets : Characters of list as is, by preferred strokes
+func preferStrokeBuckets(m map[rune]int32) [][]rune {
+	ret := make([][]rune, MaxStroke+1)
+	for r := range m {
+		c, _ := unihan.Query(r)
+		if c == nil {
+			continue
+		}
+
+		stroke := c.QueryStrokePrefer()
+		if stroke <= MaxStroke {
+			ret[stroke] = append(ret[stroke], r)
 		}
 	}
 
-	return total
+	return ret
 }
 
 // GetCommonL1 : Get rune list of L1
@@ -221,6 +250,23 @@ func GetCommonL2Traditional() map[rune]int32 {
 	return commonCharactersL2Traditional
 }
 
+// GetCommonByStroke : Get characters of level (1 or 2) by given stroke, counted by school
+func GetCommonByStroke(school, level, stroke int) []rune {
+	if stroke < 1 || stroke > MaxStroke {
+		return nil
+	}
+
+	if school < 0 || school >= StrokeSchools {
+		school = StrokeSchoolDefault
+	}
+
+	if level != 2 {
+		level = 1
+	}
+
+	return commonCharactersStroke[school][level-1][stroke]
+}
+
 // GetCommonL1ByStroke : Get characters by given stroke (L1)
 func GetCommonL1ByStroke(stroke int) []rune {
 	if stroke < 1 || stroke > MaxStroke {
@@ -262,6 +308,13 @@ func init() {
 	commonCharactersStrokeL1Traditional = make([][]rune, MaxStroke+1)
 	commonCharactersStrokeL2 = make([][]rune, MaxStroke+1)
 	commonCharactersStrokeL2Traditional = make([][]rune, MaxStroke+1)
+	commonCharactersStroke = make([][][][]rune, StrokeSchools)
+	for school := range commonCharactersStroke {
+		commonCharactersStroke[school] = [][][]rune{
+			make([][]rune, MaxStroke+1),
+			make([][]rune, MaxStroke+1),
+		}
+	}
 
 	return
 }
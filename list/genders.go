@@ -0,0 +1,109 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file genders.go
+ * @package list
+ * @since 10/17/2026
+ */
+
+package list
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Gender tags of characters
+const (
+	// GenderTagNeutral : Neutral
+	GenderTagNeutral = 0
+	// GenderTagMasculine : Masculine
+	GenderTagMasculine = 1
+	// GenderTagFeminine : Feminine
+	GenderTagFeminine = 2
+)
+
+var genderM map[rune]int
+
+// QueryCharacterGender : Check and return gender tag by given rune
+func QueryCharacterGender(r rune) int {
+	if genderM == nil {
+		return GenderTagNeutral
+	}
+
+	return genderM[r]
+}
+
+// LoadCharacterGenders : Load gender lexicon of Chinese characters
+func LoadCharacterGenders(dir string) (int, error) {
+	var (
+		fullPath string
+		f        *os.File
+		err      error
+		scanner  *bufio.Scanner
+		line     string
+		parts    []string
+		rcode    int
+		tag      int
+		total    int
+	)
+
+	genderM = make(map[rune]int)
+	fullPath = fmt.Sprintf("%s/list/CharacterGenders.txt", dir)
+	f, err = os.Open(fullPath)
+	if err != nil {
+		genderM = nil
+		return 0, fmt.Errorf("Load gender lexicon file <%s> failed : %w", fullPath, err)
+	}
+
+	scanner = bufio.NewScanner(f)
+	for scanner.Scan() == true {
+		line = scanner.Text()
+		parts = strings.Split(line, ",")
+		if 2 == len(parts) {
+			rcode, _ = strconv.Atoi(parts[0])
+			tag, _ = strconv.Atoi(parts[1])
+			if rcode > 0 && tag >= GenderTagNeutral && tag <= GenderTagFeminine {
+				genderM[rune(rcode)] = tag
+				total++
+			}
+		}
+	}
+
+	f.Close()
+
+	return total, nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,163 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file polyphones.go
+ * @package list
+ * @since 10/17/2026
+ */
+
+package list
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readings : Readings of polyphonic characters, after given previous character or in any context
+type readings struct {
+	single map[rune]string
+	pairs  map[[2]rune]string
+}
+
+var (
+	surnameReadings *readings
+	nameReadings    *readings
+)
+
+// query : Reading after previous character prefered, 0 for no previous character
+func (rs *readings) query(prev, r rune) string {
+	if rs == nil {
+		return ""
+	}
+
+	if v, ok := rs.pairs[[2]rune{prev, r}]; ok && prev > 0 {
+		return v
+	}
+
+	return rs.single[r]
+}
+
+// QuerySurnameReading : Reading of polyphonic character used as family name, after previous character of family name
+func QuerySurnameReading(prev, r rune) string {
+	return surnameReadings.query(prev, r)
+}
+
+// QueryNameReading : Reading of polyphonic character used in given name, after previous character of full name
+func QueryNameReading(prev, r rune) string {
+	return nameReadings.query(prev, r)
+}
+
+// loadReadings : Line format is "unicode:reading", or "previous unicode,unicode:reading" for reading after previous character
+func loadReadings(fullPath string) (*readings, int, error) {
+	var (
+		f       *os.File
+		err     error
+		scanner *bufio.Scanner
+		line    string
+		parts   []string
+		codes   []string
+		rcode   int
+		pcode   int
+		total   int
+		ret     = &readings{
+			single: make(map[rune]string),
+			pairs:  make(map[[2]rune]string),
+		}
+	)
+
+	f, err = os.Open(fullPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Load reading list <%s> failed : %w", fullPath, err)
+	}
+
+	scanner = bufio.NewScanner(f)
+	for scanner.Scan() == true {
+		line = scanner.Text()
+		parts = strings.Split(line, ":")
+		if 2 != len(parts) || parts[1] == "" {
+			continue
+		}
+
+		codes = strings.Split(parts[0], ",")
+		switch len(codes) {
+		case 1:
+			rcode, err = strconv.Atoi(codes[0])
+			if err == nil {
+				ret.single[rune(rcode)] = parts[1]
+				total++
+			}
+		case 2:
+			pcode, err = strconv.Atoi(codes[0])
+			if err != nil {
+				continue
+			}
+
+			rcode, err = strconv.Atoi(codes[1])
+			if err == nil && pcode > 0 {
+				ret.pairs[[2]rune{rune(pcode), rune(rcode)}] = parts[1]
+				total++
+			}
+		}
+	}
+
+	f.Close()
+
+	return ret, total, nil
+}
+
+// LoadSurnameReadings : Readings of polyphonic family names
+func LoadSurnameReadings(dir string) (int, error) {
+	var (
+		total int
+		err   error
+	)
+
+	surnameReadings, total, err = loadReadings(fmt.Sprintf("%s/list/SurnameReadings.txt", dir))
+
+	return total, err
+}
+
+// LoadNameReadings : Readings of polyphonic characters in given names
+func LoadNameReadings(dir string) (int, error) {
+	var (
+		total int
+		err   error
+	)
+
+	nameReadings, total, err = loadReadings(fmt.Sprintf("%s/list/NameReadings.txt", dir))
+
+	return total, err
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,107 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file radical_components.go
+ * @package list
+ * @since 10/17/2026
+ */
+
+package list
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var radicalComponentsM map[rune][]int
+
+// QueryRadicalComponents : Component radicals of given rune, besides its indexing radical
+func QueryRadicalComponents(r rune) []int {
+	if radicalComponentsM == nil {
+		return nil
+	}
+
+	return radicalComponentsM[r]
+}
+
+// LoadRadicalComponents : Load component radicals of Chinese characters
+func LoadRadicalComponents(dir string) (int, error) {
+	var (
+		fullPath string
+		f        *os.File
+		err      error
+		scanner  *bufio.Scanner
+		line     string
+		parts    []string
+		rcode    int
+		radical  int
+		total    int
+	)
+
+	radicalComponentsM = make(map[rune][]int)
+	fullPath = fmt.Sprintf("%s/list/RadicalComponents.txt", dir)
+	f, err = os.Open(fullPath)
+	if err != nil {
+		radicalComponentsM = nil
+		return 0, fmt.Errorf("Load radical components file <%s> failed : %w", fullPath, err)
+	}
+
+	// Line : unicode,radical radical ...
+	scanner = bufio.NewScanner(f)
+	for scanner.Scan() == true {
+		line = scanner.Text()
+		parts = strings.Split(line, ",")
+		if 2 == len(parts) {
+			rcode, _ = strconv.Atoi(parts[0])
+			if rcode <= 0 {
+				continue
+			}
+
+			for _, v := range strings.Fields(parts[1]) {
+				radical, _ = strconv.Atoi(v)
+				if radical >= 1 && radical <= 214 {
+					radicalComponentsM[rune(rcode)] = append(radicalComponentsM[rune(rcode)], radical)
+				}
+			}
+
+			total++
+		}
+	}
+
+	f.Close()
+
+	return total, nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,188 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file stroke_schools.go
+ * @package list
+ * @since 10/17/2026
+ */
+
+package list
+
+import (
+	"strings"
+
+	"yixuan_naming/unihan"
+)
+
+// Stroke counting schools
+const (
+	// StrokeSchoolKumazaki : Traditional form, radicals counted by origin (Kumazaki)
+	StrokeSchoolKumazaki = iota
+	// StrokeSchoolKangxi : Traditional form, total strokes of Kangxi glyph
+	StrokeSchoolKangxi
+	// StrokeSchoolSimplified : Simplified form, total strokes of simplified glyph
+	StrokeSchoolSimplified
+	// StrokeSchools : Number of stroke counting schools
+	StrokeSchools
+
+	// StrokeSchoolDefault : Default stroke counting school
+	StrokeSchoolDefault = StrokeSchoolKumazaki
+)
+
+// StrokeCounter : Stroke counting strategy of school
+type StrokeCounter interface {
+	// Traditional : Strokes counted on traditional form, or on simplified form
+	Traditional() bool
+	// Count : Strokes of character already in counted form
+	Count(c *unihan.HanCharacter) int
+}
+
+type kumazakiCounter struct{}
+type kangxiCounter struct{}
+type simplifiedCounter struct{}
+
+var (
+	// Numerals counted by value in Kumazaki school
+	kumazakiNumerals = map[rune]int{
+		'一': 1, '二': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9, '十': 10,
+	}
+
+	strokeCounters = []StrokeCounter{
+		kumazakiCounter{},
+		kangxiCounter{},
+		simplifiedCounter{},
+	}
+)
+
+func (kumazakiCounter) Traditional() bool {
+	return true
+}
+
+// Count : Special list, numerals, then radical-stroke of unicode (radical origin)
+func (kumazakiCounter) Count(c *unihan.HanCharacter) int {
+	if stroke := QueryStrokeSpecial(c.Unicode); stroke > 0 {
+		return stroke
+	}
+
+	if stroke := kumazakiNumerals[c.Unicode]; stroke > 0 {
+		return stroke
+	}
+
+	return c.QueryStrokePrefer()
+}
+
+func (kangxiCounter) Traditional() bool {
+	return true
+}
+
+// Count : Total strokes of traditional glyph
+func (kangxiCounter) Count(c *unihan.HanCharacter) int {
+	if strokes := c.QueryTotalStrokes(); len(strokes) > 0 {
+		return strokes[len(strokes)-1]
+	}
+
+	return c.QueryStrokePrefer()
+}
+
+func (simplifiedCounter) Traditional() bool {
+	return false
+}
+
+// Count : Total strokes of simplified glyph
+func (simplifiedCounter) Count(c *unihan.HanCharacter) int {
+	if strokes := c.QueryTotalStrokes(); len(strokes) > 0 {
+		return strokes[0]
+	}
+
+	return c.QueryStrokePrefer()
+}
+
+// AssertStrokeSchool : Stroke counting school of name or number
+func AssertStrokeSchool(school string) (int, bool) {
+	switch strings.ToLower(school) {
+	case "0", "kumazaki":
+		return StrokeSchoolKumazaki, true
+	case "1", "kangxi":
+		return StrokeSchoolKangxi, true
+	case "2", "simplified":
+		return StrokeSchoolSimplified, true
+	}
+
+	return StrokeSchoolDefault, false
+}
+
+// GetStrokeCounter : Stroke counter of school, default school if invalid
+func GetStrokeCounter(school int) StrokeCounter {
+	if school < 0 || school >= len(strokeCounters) {
+		school = StrokeSchoolDefault
+	}
+
+	return strokeCounters[school]
+}
+
+// QuerySchoolForm : Character form counted by school
+func QuerySchoolForm(school int, r rune) *unihan.HanCharacter {
+	var form rune
+
+	c, _ := unihan.Query(r)
+	if c == nil {
+		return nil
+	}
+
+	if GetStrokeCounter(school).Traditional() {
+		form = QueryTraditionalSpecial(r)
+		if form == 0 {
+			form, _ = c.QueryTraditionalLazy()
+		}
+	} else {
+		form, _ = c.QuerySimplifiedPrefer()
+	}
+
+	if form > 0 && form != r {
+		if fc, _ := unihan.Query(form); fc != nil {
+			return fc
+		}
+	}
+
+	return c
+}
+
+// QuerySchoolStroke : Strokes of character in counted form of school, 0 if unknown
+func QuerySchoolStroke(school int, r rune) int {
+	c := QuerySchoolForm(school, r)
+	if c == nil {
+		return 0
+	}
+
+	return GetStrokeCounter(school).Count(c)
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,233 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file symbolic_stars.go
+ * @package list
+ * @since 10/17/2026
+ */
+
+package list
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Bases of symbolic star rules
+const (
+	// StarBaseYearGan : Tiangan of year pillar
+	StarBaseYearGan = iota
+	// StarBaseYearZhi : Dizhi of year pillar
+	StarBaseYearZhi
+	// StarBaseMonthZhi : Dizhi of month pillar
+	StarBaseMonthZhi
+	// StarBaseDayGan : Tiangan of day pillar
+	StarBaseDayGan
+	// StarBaseDayZhi : Dizhi of day pillar
+	StarBaseDayZhi
+)
+
+// Targets of symbolic star rules
+const (
+	// StarTargetGan : Tiangans of four pillars
+	StarTargetGan = iota
+	// StarTargetZhi : Dizhis of four pillars
+	StarTargetZhi
+)
+
+const (
+	gans = "甲乙丙丁戊己庚辛壬癸"
+	zhis = "子丑寅卯辰巳午未申酉戌亥"
+)
+
+// SymbolicStarRule : Rule of symbolic star (ShenSha), Table[base value] lists target values
+type SymbolicStarRule struct {
+	ID     int
+	Bases  []int
+	Target int
+	Table  [][]int
+}
+
+var (
+	starBases = map[string]int{
+		"yg": StarBaseYearGan,
+		"yz": StarBaseYearZhi,
+		"mz": StarBaseMonthZhi,
+		"dg": StarBaseDayGan,
+		"dz": StarBaseDayZhi,
+	}
+	starTargets = map[string]int{
+		"gan": StarTargetGan,
+		"zhi": StarTargetZhi,
+	}
+	symbolicStarRules []*SymbolicStarRule
+)
+
+// SymbolicStarRules : Loaded symbolic star rules
+func SymbolicStarRules() []*SymbolicStarRule {
+	return symbolicStarRules
+}
+
+// StarBaseIsGan : If base is a tiangan
+func StarBaseIsGan(base int) bool {
+	return base == StarBaseYearGan || base == StarBaseDayGan
+}
+
+// parseStarTable : Table of 10 (tiangan) or 12 (dizhi) entries, "/" separated alternatives, "-" for none
+func parseStarTable(s string, size int, target int) ([][]int, error) {
+	var (
+		entries = strings.Split(s, ",")
+		symbols = []rune(gans)
+		ret     [][]int
+	)
+
+	if target == StarTargetZhi {
+		symbols = []rune(zhis)
+	}
+
+	_index := func(r rune) int {
+		for i, v := range symbols {
+			if v == r {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	if len(entries) != size {
+		return nil, fmt.Errorf("Table size %d, %d expected", len(entries), size)
+	}
+
+	for _, entry := range entries {
+		var values []int
+		for _, r := range strings.TrimSpace(entry) {
+			if r == '/' || r == '-' {
+				continue
+			}
+
+			v := _index(r)
+			if v < 0 {
+				return nil, fmt.Errorf("Invalid symbol <%c>", r)
+			}
+
+			values = append(values, v)
+		}
+
+		ret = append(ret, values)
+	}
+
+	return ret, nil
+}
+
+// LoadSymbolicStars : Load rules of symbolic stars, one rule per line as "id|bases|target|table", for example
+//
+//	# TianYiGuiRen, by day or year tiangan
+//	0|dg,yg|zhi|丑/未,子/申,亥/酉,亥/酉,丑/未,子/申,丑/未,寅/午,卯/巳,卯/巳
+//	# HuaGai, by year or day dizhi
+//	4|yz,dz|zhi|辰,丑,戌,未,辰,丑,戌,未,辰,丑,戌,未
+//
+// Names and descriptions of stars are messages indexed by id
+func LoadSymbolicStars(dir string) (int, error) {
+	var (
+		f        *os.File
+		err      error
+		scanner  *bufio.Scanner
+		line     string
+		parts    []string
+		rules    []*SymbolicStarRule
+		fullPath = fmt.Sprintf("%s/list/SymbolicStars.txt", dir)
+	)
+
+	f, err = os.Open(fullPath)
+	if err != nil {
+		return 0, fmt.Errorf("Load symbolic star rules <%s> failed : %w", fullPath, err)
+	}
+
+	defer f.Close()
+
+	scanner = bufio.NewScanner(f)
+	for n := 1; scanner.Scan() == true; n++ {
+		line = strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts = strings.Split(line, "|")
+		if len(parts) != 4 {
+			return 0, fmt.Errorf("Invalid symbolic star rule at line %d of <%s>", n, fullPath)
+		}
+
+		rule := &SymbolicStarRule{}
+		rule.ID, err = strconv.Atoi(parts[0])
+		if err != nil || rule.ID < 0 {
+			return 0, fmt.Errorf("Invalid symbolic star id at line %d of <%s>", n, fullPath)
+		}
+
+		target, ok := starTargets[parts[2]]
+		if !ok {
+			return 0, fmt.Errorf("Invalid symbolic star target at line %d of <%s>", n, fullPath)
+		}
+
+		rule.Target = target
+		isGan := false
+		for i, b := range strings.Split(parts[1], ",") {
+			base, ok := starBases[strings.TrimSpace(b)]
+			if !ok || (i > 0 && StarBaseIsGan(base) != isGan) {
+				return 0, fmt.Errorf("Invalid symbolic star base at line %d of <%s>", n, fullPath)
+			}
+
+			isGan = StarBaseIsGan(base)
+			rule.Bases = append(rule.Bases, base)
+		}
+
+		size := 12
+		if isGan {
+			size = 10
+		}
+
+		rule.Table, err = parseStarTable(parts[3], size, rule.Target)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid symbolic star table at line %d of <%s> : %s", n, fullPath, err.Error())
+		}
+
+		rules = append(rules, rule)
+	}
+
+	symbolicStarRules = rules
+
+	return len(rules), nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
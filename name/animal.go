@@ -31,10 +31,24 @@
 package name
 
 import (
+	"yixuan_naming/list"
 	"yixuan_naming/texts"
+	"yixuan_naming/unihan"
 	"yixuan_naming/utils"
 )
 
+// Zodiac radical scores of character
+const (
+	// AnimalScoreLucky : Lucky radicals only
+	AnimalScoreLucky = 100
+	// AnimalScoreNeutral : No radical of zodiac template
+	AnimalScoreNeutral = 60
+	// AnimalScoreMixed : Both lucky and ominous radicals
+	AnimalScoreMixed = 40
+	// AnimalScoreOminous : Ominous radicals only
+	AnimalScoreOminous = 10
+)
+
 type radicalsMeaning struct {
 	RadicalIndexes []int                   `json:"radical_indexes"`
 	Radicals       []*utils.ChineseRadical `json:"radicals"`
@@ -51,9 +65,26 @@ type animalRadicalsTpl struct {
 	ominous [][]int
 }
 
+// animalFit : Radicals of character matched against zodiac template
+type animalFit struct {
+	Character string                  `json:"character"`
+	Radicals  []*utils.ChineseRadical `json:"radicals"`
+	Lucky     []int                   `json:"lucky,omitempty"`
+	Ominous   []int                   `json:"ominous,omitempty"`
+	Score     int                     `json:"score"`
+}
+
+type animalWarning struct {
+	Character string                `json:"character"`
+	Radical   *utils.ChineseRadical `json:"radical"`
+	Meaning   string                `json:"meaning"`
+}
+
 type animal struct {
-	Radicals *animalRadicals `json:"radicals"`
-	Years    string          `json:"years"`
+	Radicals   *animalRadicals `json:"radicals"`
+	Years      string          `json:"years"`
+	Characters []*animalFit    `json:"characters,omitempty"`
+	Warnings   []animalWarning `json:"warnings,omitempty"`
 }
 
 var (
@@ -283,6 +314,114 @@ func getAnimalRadicals(index int, language int) *animalRadicals {
 	return ret
 }
 
+// characterRadicals : Radicals of character forms, indexing radicals and known components
+func characterRadicals(runes ...rune) []int {
+	var ret []int
+	_add := func(radicals []int) {
+		for _, v := range radicals {
+			found := false
+			for _, x := range ret {
+				if x == v {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				ret = append(ret, v)
+			}
+		}
+	}
+
+	for _, r := range runes {
+		c, _ := unihan.Query(r)
+		if c != nil {
+			_add(c.QueryRadicals())
+		}
+
+		_add(list.QueryRadicalComponents(r))
+	}
+
+	return ret
+}
+
+// matchRadicals : Indexes of template groups containing any of radicals, with the radical hit of each
+func matchRadicals(groups [][]int, radicals []int) (matched []int, hits []int) {
+	for i, group := range groups {
+	search:
+		for _, g := range group {
+			for _, r := range radicals {
+				if g == r {
+					matched = append(matched, i)
+					hits = append(hits, r)
+					break search
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// fitAnimal : Match radicals of character forms against zodiac template of index
+func fitAnimal(index int, runes ...rune) (*animalFit, []int) {
+	if index < 0 || index > 11 || len(runes) == 0 {
+		return nil, nil
+	}
+
+	var (
+		tpl       = &animalRadicalsTplList[index]
+		radicals  = characterRadicals(runes...)
+		ret       = &animalFit{Character: string(runes[0])}
+		ominousRs []int
+	)
+
+	for _, r := range radicals {
+		ret.Radicals = append(ret.Radicals, utils.GetRadical(r))
+	}
+
+	ret.Lucky, _ = matchRadicals(tpl.lucky, radicals)
+	ret.Ominous, ominousRs = matchRadicals(tpl.ominous, radicals)
+	switch {
+	case len(ret.Lucky) > 0 && len(ret.Ominous) > 0:
+		ret.Score = AnimalScoreMixed
+	case len(ret.Lucky) > 0:
+		ret.Score = AnimalScoreLucky
+	case len(ret.Ominous) > 0:
+		ret.Score = AnimalScoreOminous
+	default:
+		ret.Score = AnimalScoreNeutral
+	}
+
+	return ret, ominousRs
+}
+
+// filterAnimal : Remove characters with ominous radicals of zodiac index
+func filterAnimal(runes []rune, index int) []rune {
+	if index < 0 || index > 11 {
+		return runes
+	}
+
+	var ret []rune
+	for _, r := range runes {
+		forms := []rune{r}
+		c, _ := unihan.Query(r)
+		if c != nil {
+			rs, _ := c.QuerySimplifiedPrefer()
+			if rs > 0 && rs != r {
+				forms = append(forms, rs)
+			}
+		}
+
+		fit, _ := fitAnimal(index, forms...)
+		if len(fit.Ominous) == 0 {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret
+}
+
 /*
  * Local variables:
  * tab-width: 4
@@ -0,0 +1,81 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file chart.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"yixuan_naming/calendar"
+	"yixuan_naming/texts"
+	"yixuan_naming/utils"
+)
+
+// ChartData : Birth chart (eight characters with luck) without name
+type ChartData struct {
+	language           int
+	Calendar           *calendar.Calendar     `json:"calendar"`
+	EightCharacters    eightCharacters        `json:"eight_characters"`
+	GanzhiFiveElements GanzhiFiveElementsSpec `json:"ganzhi_five_elements"`
+	Luck               *luck                  `json:"luck"`
+}
+
+// Chart : Birth chart with luck pillars, and annual pillars of years from fromYear
+func Chart(language int, birthTime int64, loc utils.Location, ziHour, gender, fromYear, years int) *ChartData {
+	chart := &ChartData{language: language}
+
+	chart.Calendar = calendar.New(birthTime, loc, ziHour)
+	chart.Calendar.Ganzhi.YearString = chart.Calendar.Ganzhi.Year.String(chart.language)
+	chart.Calendar.Ganzhi.MonthString = chart.Calendar.Ganzhi.Month.String(chart.language)
+	chart.Calendar.Ganzhi.DayString = chart.Calendar.Ganzhi.Day.String(chart.language)
+	chart.Calendar.Ganzhi.HourString = chart.Calendar.Ganzhi.Hour.String(chart.language)
+	chart.Calendar.Ganzhi.ZiHourString = texts.GetAlias(texts.AliasZiHour, chart.Calendar.Ganzhi.ZiHour, chart.language)
+
+	chart.EightCharacters.Year = &chart.Calendar.Ganzhi.Year
+	chart.EightCharacters.Month = &chart.Calendar.Ganzhi.Month
+	chart.EightCharacters.Day = &chart.Calendar.Ganzhi.Day
+	chart.EightCharacters.Hour = &chart.Calendar.Ganzhi.Hour
+	chart.EightCharacters.complete()
+	chart.EightCharacters.calculateTenGods(chart.language)
+	chart.EightCharacters.localize(chart.language)
+
+	chart.GanzhiFiveElements = GanzhiFiveElements(chart.Calendar)
+	chart.GanzhiFiveElements.adjust(&chart.EightCharacters)
+	chart.GanzhiFiveElements.weigh(&chart.EightCharacters, chart.language)
+	chart.Luck = calculateLuck(chart.Calendar, gender, fromYear, years, chart.language)
+
+	return chart
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,193 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file climate.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"strconv"
+
+	"yixuan_naming/texts"
+	"yixuan_naming/utils"
+)
+
+// Seasons by month dizhi
+const (
+	// SeasonSpring : Yin, Mao, Chen
+	SeasonSpring = iota
+	// SeasonSummer : Si, Wu, Wei
+	SeasonSummer
+	// SeasonAutumn : Shen, You, Xu
+	SeasonAutumn
+	// SeasonWinter : Hai, Zi, Chou
+	SeasonWinter
+)
+
+// Climates of seasons
+const (
+	// ClimateMild : Needs no adjustment
+	ClimateMild = iota
+	// ClimateHot : Needs water
+	ClimateHot
+	// ClimateDry : Needs water
+	ClimateDry
+	// ClimateCold : Needs fire
+	ClimateCold
+)
+
+// Steps of favourable element reasoning
+const (
+	// ReasonLing : Ling of month
+	ReasonLing = iota
+	// ReasonShi : Shi of other pillars
+	ReasonShi
+	// ReasonDi : Rooted in day dizhi
+	ReasonDi
+	// ReasonStrength : Strong or weak day master
+	ReasonStrength
+	// ReasonStrengthLike : Favourable element by strength
+	ReasonStrengthLike
+	// ReasonSeason : Season of month dizhi
+	ReasonSeason
+	// ReasonClimate : Climate of season
+	ReasonClimate
+	// ReasonClimateNeed : Element needed by climate
+	ReasonClimateNeed
+	// ReasonClimatePresent : Count of needed element in pillars
+	ReasonClimatePresent
+	// ReasonClimateLike : Favourable element by climate
+	ReasonClimateLike
+)
+
+const (
+	// ClimateRelievedCount : Climate relieved if needed element presents this many times
+	ClimateRelievedCount = 2
+)
+
+var (
+	seasonClimates = []int{ClimateMild, ClimateHot, ClimateDry, ClimateCold}
+	climateNeeds   = []int{utils.ElementUnknown, utils.ElementWater, utils.ElementWater, utils.ElementFire}
+)
+
+type reasonStep struct {
+	alias      int
+	Step       int    `json:"step"`
+	StepAlias  string `json:"step_alias"`
+	Value      int    `json:"value"`
+	ValueAlias string `json:"value_alias"`
+}
+
+type favourable struct {
+	StrengthLike      int           `json:"strength_like"`
+	StrengthLikeAlias string        `json:"strength_like_alias"`
+	Season            int           `json:"season"`
+	SeasonAlias       string        `json:"season_alias"`
+	Climate           int           `json:"climate"`
+	ClimateAlias      string        `json:"climate_alias"`
+	ClimateNeed       int           `json:"climate_need"`
+	ClimateNeedAlias  string        `json:"climate_need_alias"`
+	ClimateLike       int           `json:"climate_like"`
+	ClimateLikeAlias  string        `json:"climate_like_alias"`
+	Reasons           []*reasonStep `json:"reasons"`
+}
+
+// calculateFavourable : Favourable elements by strength (YiXuan) and by seasonal climate (TiaoHou),
+// climate prevails unless needed element already presents in pillars
+func (ec *eightCharacters) calculateFavourable() {
+	var (
+		f      = &ec.Favourable
+		season = ((ec.Month.DiZhi + 10) % 12) / 3
+		di     = 0
+		strong = 0
+	)
+
+	if ec.Di {
+		di = 1
+	}
+
+	if ec.StretchYi {
+		strong = 1
+	}
+
+	f.StrengthLike = ec.LikeYi
+	f.Season = season
+	f.Climate = seasonClimates[season]
+	f.ClimateNeed = climateNeeds[f.Climate]
+	f.ClimateLike = f.StrengthLike
+
+	present := 0
+	for _, e := range ec.elements {
+		if e == f.ClimateNeed {
+			present++
+		}
+	}
+
+	if f.ClimateNeed != utils.ElementUnknown && present < ClimateRelievedCount {
+		f.ClimateLike = f.ClimateNeed
+	}
+
+	f.Reasons = []*reasonStep{
+		{Step: ReasonLing, Value: ec.Ling, alias: -1},
+		{Step: ReasonShi, Value: ec.ShiYi, alias: -1},
+		{Step: ReasonDi, Value: di, alias: texts.AliasYesNo},
+		{Step: ReasonStrength, Value: strong, alias: texts.AliasStrength},
+		{Step: ReasonStrengthLike, Value: f.StrengthLike, alias: texts.AliasFiveElement},
+		{Step: ReasonSeason, Value: f.Season, alias: texts.AliasSeason},
+		{Step: ReasonClimate, Value: f.Climate, alias: texts.AliasClimate},
+		{Step: ReasonClimateNeed, Value: f.ClimateNeed, alias: texts.AliasFiveElement},
+		{Step: ReasonClimatePresent, Value: present, alias: -1},
+		{Step: ReasonClimateLike, Value: f.ClimateLike, alias: texts.AliasFiveElement},
+	}
+}
+
+// localizeFavourable : Aliases of favourable elements and reasoning steps
+func (ec *eightCharacters) localizeFavourable(language int) {
+	f := &ec.Favourable
+	f.StrengthLikeAlias = texts.GetAlias(texts.AliasFiveElement, f.StrengthLike, language)
+	f.SeasonAlias = texts.GetAlias(texts.AliasSeason, f.Season, language)
+	f.ClimateAlias = texts.GetAlias(texts.AliasClimate, f.Climate, language)
+	f.ClimateNeedAlias = texts.GetAlias(texts.AliasFiveElement, f.ClimateNeed, language)
+	f.ClimateLikeAlias = texts.GetAlias(texts.AliasFiveElement, f.ClimateLike, language)
+	for _, r := range f.Reasons {
+		r.StepAlias = texts.GetAlias(texts.AliasReasonStep, r.Step, language)
+		if r.alias < 0 {
+			r.ValueAlias = strconv.Itoa(r.Value)
+		} else {
+			r.ValueAlias = texts.GetAlias(r.alias, r.Value, language)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
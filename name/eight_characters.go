@@ -35,25 +35,48 @@ import (
 )
 
 type eightCharacters struct {
-	Year      *utils.GanzhiPair `json:"year"`
-	Month     *utils.GanzhiPair `json:"month"`
-	Day       *utils.GanzhiPair `json:"day"`
-	Hour      *utils.GanzhiPair `json:"hour"`
-	Ling      int               `json:"ling"`
-	Shi       int               `json:"shi"`
-	ShiYi     int               `json:"shi_yi"`
-	Di        bool              `json:"di"`
-	Self      int               `json:"self"`
-	Like      int               `json:"like"`
-	LikeYi    int               `json:"like_yi"`
-	Stretch   bool              `json:"stretch"`
-	StretchYi bool              `json:"stretch_yi"`
+	Year       *utils.GanzhiPair `json:"year"`
+	Month      *utils.GanzhiPair `json:"month"`
+	Day        *utils.GanzhiPair `json:"day"`
+	Hour       *utils.GanzhiPair `json:"hour"`
+	Ling       int               `json:"ling"`
+	Shi        int               `json:"shi"`
+	ShiYi      int               `json:"shi_yi"`
+	Di         bool              `json:"di"`
+	Self       int               `json:"self"`
+	Like       int               `json:"like"`
+	LikeYi     int               `json:"like_yi"`
+	Stretch    bool              `json:"stretch"`
+	StretchYi  bool              `json:"stretch_yi"`
+	TenGods    tenGods           `json:"ten_gods"`
+	Relations  []*relation       `json:"relations"`
+	Stars      []*symbolicStar   `json:"stars"`
+	Voids      []*void           `json:"voids"`
+	Favourable favourable        `json:"favourable"`
+
+	// Effective five-elements of year gan, year zhi ... hour zhi after transformations
+	elements []int
+}
+
+// pillars : Four pillars in order
+func (ec *eightCharacters) pillars() []*utils.GanzhiPair {
+	return []*utils.GanzhiPair{ec.Year, ec.Month, ec.Day, ec.Hour}
+}
+
+// ganElement : Effective five-element of tiangan of pillar
+func (ec *eightCharacters) ganElement(pillar int) int {
+	return ec.elements[pillar*2]
+}
+
+// zhiElement : Effective five-element of dizhi of pillar
+func (ec *eightCharacters) zhiElement(pillar int) int {
+	return ec.elements[pillar*2+1]
 }
 
 func (ec *eightCharacters) calculateLing() int {
 	var (
 		feDay   = utils.GanFiveElement(ec.Day.TianGan)
-		feMonth = utils.ZhiFiveElement(ec.Month.DiZhi)
+		feMonth = ec.zhiElement(PillarMonth)
 		c, l    int
 	)
 
@@ -81,13 +104,13 @@ func (ec *eightCharacters) calculateShi() (int, int) {
 	)
 
 	for _, c := range []int{
-		utils.GanFiveElement(ec.Year.TianGan),
-		utils.ZhiFiveElement(ec.Year.DiZhi),
-		utils.GanFiveElement(ec.Month.TianGan),
-		//utils.ZhiFiveElement(ec.Month.DiZhi),
-		utils.ZhiFiveElement(ec.Day.DiZhi),
-		utils.GanFiveElement(ec.Hour.TianGan),
-		utils.ZhiFiveElement(ec.Hour.DiZhi),
+		ec.ganElement(PillarYear),
+		ec.zhiElement(PillarYear),
+		ec.ganElement(PillarMonth),
+		//ec.zhiElement(PillarMonth),
+		ec.zhiElement(PillarDay),
+		ec.ganElement(PillarHour),
+		ec.zhiElement(PillarHour),
 	} {
 		//fmt.Println("Shi", c, feDay)
 		switch utils.CompareFiveElements(c, feDay) {
@@ -98,8 +121,8 @@ func (ec *eightCharacters) calculateShi() (int, int) {
 		}
 	}
 
-	//fmt.Println("ShiYi", utils.ZhiFiveElement(ec.Month.DiZhi), feDay)
-	switch utils.CompareFiveElements(utils.ZhiFiveElement(ec.Month.DiZhi), feDay) {
+	//fmt.Println("ShiYi", ec.zhiElement(PillarMonth), feDay)
+	switch utils.CompareFiveElements(ec.zhiElement(PillarMonth), feDay) {
 	case utils.FiveElementEqual, utils.FiveElementBirth:
 		ret = retYi + 10
 	default:
@@ -147,6 +170,8 @@ func (ec *eightCharacters) calculateDi() bool {
 }
 
 func (ec *eightCharacters) complete() {
+	ec.calculateRelations()
+	ec.calculateSymbolicStars()
 	ec.Ling = ec.calculateLing()
 	ec.Shi, ec.ShiYi = ec.calculateShi()
 	ec.Di = ec.calculateDi()
@@ -192,45 +217,45 @@ func (ec *eightCharacters) complete() {
 	}
 
 	feDay := utils.GanFiveElement(ec.Day.TianGan)
-	if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Month.DiZhi)) == utils.FiveElementKilled {
-		if utils.CompareFiveElements(feDay, utils.GanFiveElement(ec.Month.TianGan)) == utils.FiveElementBirthed &&
-			utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Day.DiZhi)) == utils.FiveElementBirthed {
+	if utils.CompareFiveElements(feDay, ec.zhiElement(PillarMonth)) == utils.FiveElementKilled {
+		if utils.CompareFiveElements(feDay, ec.ganElement(PillarMonth)) == utils.FiveElementBirthed &&
+			utils.CompareFiveElements(feDay, ec.zhiElement(PillarDay)) == utils.FiveElementBirthed {
 			ec.StretchYi = true
 		}
 	}
 
 	yi2 := 0
 	yi4 := 0
-	if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Month.DiZhi)) == utils.FiveElementBirthed {
-		if utils.CompareFiveElements(feDay, utils.GanFiveElement(ec.Month.TianGan)) == utils.FiveElementKill {
+	if utils.CompareFiveElements(feDay, ec.zhiElement(PillarMonth)) == utils.FiveElementBirthed {
+		if utils.CompareFiveElements(feDay, ec.ganElement(PillarMonth)) == utils.FiveElementKill {
 			yi2++
 		}
-		if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Day.DiZhi)) == utils.FiveElementKill {
+		if utils.CompareFiveElements(feDay, ec.zhiElement(PillarDay)) == utils.FiveElementKill {
 			yi2++
 		}
-		if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Year.DiZhi)) == utils.FiveElementKill {
+		if utils.CompareFiveElements(feDay, ec.zhiElement(PillarYear)) == utils.FiveElementKill {
 			yi2++
 		}
-		if utils.CompareFiveElements(feDay, utils.GanFiveElement(ec.Month.TianGan)) == utils.FiveElementBirthed {
+		if utils.CompareFiveElements(feDay, ec.ganElement(PillarMonth)) == utils.FiveElementBirthed {
 			yi4++
 		}
-		if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Day.DiZhi)) == utils.FiveElementKilled {
+		if utils.CompareFiveElements(feDay, ec.zhiElement(PillarDay)) == utils.FiveElementKilled {
 			yi4++
 		}
-		if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Year.DiZhi)) == utils.FiveElementKilled {
+		if utils.CompareFiveElements(feDay, ec.zhiElement(PillarYear)) == utils.FiveElementKilled {
 			yi4++
 		}
 	}
 
 	yi3 := 0
 	if ec.Ling == 50 {
-		if utils.CompareFiveElements(feDay, utils.GanFiveElement(ec.Month.TianGan)) == utils.FiveElementKilled {
+		if utils.CompareFiveElements(feDay, ec.ganElement(PillarMonth)) == utils.FiveElementKilled {
 			yi3++
 		}
-		if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Day.DiZhi)) == utils.FiveElementKilled {
+		if utils.CompareFiveElements(feDay, ec.zhiElement(PillarDay)) == utils.FiveElementKilled {
 			yi3++
 		}
-		if utils.CompareFiveElements(feDay, utils.ZhiFiveElement(ec.Year.DiZhi)) == utils.FiveElementKilled {
+		if utils.CompareFiveElements(feDay, ec.zhiElement(PillarYear)) == utils.FiveElementKilled {
 			yi3++
 		}
 	}
@@ -252,6 +277,15 @@ func (ec *eightCharacters) complete() {
 	if ec.LikeYi >= 5 {
 		ec.LikeYi -= 5
 	}
+
+	ec.calculateFavourable()
+}
+
+// localize : Aliases of relations, symbolic stars, voids and favourable elements
+func (ec *eightCharacters) localize(language int) {
+	ec.localizeRelations(language)
+	ec.localizeSymbolicStars(language)
+	ec.localizeFavourable(language)
 }
 
 /*
@@ -0,0 +1,246 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file family.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"fmt"
+	"sort"
+
+	"yixuan_naming/texts"
+	"yixuan_naming/utils"
+)
+
+// Roles of family members
+const (
+	// FamilyChild : The child to be named
+	FamilyChild = iota
+	// FamilyFather : Father
+	FamilyFather
+	// FamilyMother : Mother
+	FamilyMother
+	// FamilySibling : Brother or sister
+	FamilySibling
+)
+
+const (
+	// MaxFamilyMembers : Maximum members (child included) of family compatibility
+	MaxFamilyMembers = 8
+	// FamilyRecommends : Number of recommended elements for name of child
+	FamilyRecommends = 2
+)
+
+// FamilyMember : Birth input of family member
+type FamilyMember struct {
+	Role      int
+	BirthTime int64
+	Location  utils.Location
+}
+
+type familyMemberData struct {
+	Index           int        `json:"index"`
+	Role            int        `json:"role"`
+	RoleAlias       string     `json:"role_alias"`
+	Animal          int        `json:"animal"`
+	AnimalAlias     string     `json:"animal_alias"`
+	DayMaster       int        `json:"day_master"`
+	DayMasterAlias  string     `json:"day_master_alias"`
+	DayElement      int        `json:"day_element"`
+	DayElementAlias string     `json:"day_element_alias"`
+	Chart           *ChartData `json:"chart"`
+}
+
+type familyPair struct {
+	Members              []int    `json:"members"`
+	Zodiac               []int    `json:"zodiac"`
+	ZodiacAlias          []string `json:"zodiac_alias"`
+	DayMasterGod         int      `json:"day_master_god"`
+	DayMasterGodAlias    string   `json:"day_master_god_alias"`
+	DayMasterCombine     bool     `json:"day_master_combine"`
+	ElementRelation      int      `json:"element_relation"`
+	ElementRelationAlias string   `json:"element_relation_alias"`
+}
+
+type elementAdvice struct {
+	Element int    `json:"element"`
+	Alias   string `json:"alias"`
+	Family  int    `json:"family"`
+	Score   int    `json:"score"`
+}
+
+// FamilyData : Compatibility of child with family members
+type FamilyData struct {
+	language       int
+	Members        []*familyMemberData     `json:"members"`
+	Pairs          []*familyPair           `json:"pairs"`
+	FamilyElements utils.FiveElementsCount `json:"family_elements"`
+	Advices        []*elementAdvice        `json:"advices"`
+	Recommends     []int                   `json:"recommends"`
+	RecommendAlias string                  `json:"recommend_alias"`
+}
+
+// zodiacRelations : Combinations and conflicts between two animal signs (dizhis)
+func zodiacRelations(a, b int) []int {
+	var ret []int
+	if (a+b)%12 == 1 {
+		ret = append(ret, RelationSixCombine)
+	}
+
+	if a != b && a%4 == b%4 {
+		ret = append(ret, RelationThreeCombine)
+	}
+
+	return append(ret, zhiConflicts(a, b)...)
+}
+
+func (family *FamilyData) calculatePairs() {
+	for i := 0; i < len(family.Members); i++ {
+		for j := i + 1; j < len(family.Members); j++ {
+			a, b := family.Members[i], family.Members[j]
+			p := &familyPair{
+				Members:          []int{i, j},
+				Zodiac:           zodiacRelations(a.Animal, b.Animal),
+				DayMasterGod:     utils.CompareGan(a.DayMaster, b.DayMaster),
+				DayMasterCombine: a.DayMaster != b.DayMaster && a.DayMaster%5 == b.DayMaster%5,
+				ElementRelation:  utils.CompareFiveElements(a.DayElement, b.DayElement),
+			}
+
+			p.ZodiacAlias = make([]string, 0, len(p.Zodiac))
+			for _, t := range p.Zodiac {
+				p.ZodiacAlias = append(p.ZodiacAlias, texts.GetAlias(texts.AliasRelation, t, family.language))
+			}
+
+			p.DayMasterGodAlias = texts.GetAlias(texts.AliasTenGod, p.DayMasterGod, family.language)
+			p.ElementRelationAlias = texts.GetAlias(texts.AliasFiveElementRelation, p.ElementRelation, family.language)
+			family.Pairs = append(family.Pairs, p)
+		}
+	}
+}
+
+// calculateAdvices : Score elements for name of child, by favourable elements of child and balance of family
+func (family *FamilyData) calculateAdvices() {
+	child := &family.Members[0].Chart.EightCharacters.Favourable
+	for _, m := range family.Members {
+		for e := utils.ElementWood; e <= utils.ElementWater; e++ {
+			family.FamilyElements.Add(e, m.Chart.GanzhiFiveElements.FiveElementsAdjusted.Get(e))
+		}
+	}
+
+	min, max := family.FamilyElements.MinMax()
+	for e := utils.ElementWood; e <= utils.ElementWater; e++ {
+		a := &elementAdvice{
+			Element: e,
+			Alias:   texts.GetAlias(texts.AliasFiveElement, e, family.language),
+			Family:  family.FamilyElements.Get(e),
+		}
+
+		if e == child.StrengthLike {
+			a.Score += 40
+		}
+
+		if e == child.ClimateLike {
+			a.Score += 20
+		}
+
+		if max > min {
+			a.Score += 40 * (max - a.Family) / (max - min)
+		}
+
+		if utils.CompareFiveElements(e, child.StrengthLike) == utils.FiveElementKill {
+			a.Score -= 40
+		}
+
+		if a.Score < 0 {
+			a.Score = 0
+		}
+
+		family.Advices = append(family.Advices, a)
+	}
+
+	sort.SliceStable(family.Advices, func(i, j int) bool {
+		return family.Advices[i].Score > family.Advices[j].Score
+	})
+
+	for _, a := range family.Advices {
+		if len(family.Recommends) >= FamilyRecommends || a.Score <= 0 {
+			break
+		}
+
+		family.Recommends = append(family.Recommends, a.Element)
+		family.RecommendAlias += a.Alias
+	}
+}
+
+// Family : Compatibility of child (first member) with parents and siblings
+func Family(language int, members []*FamilyMember, ziHour int) (*FamilyData, error) {
+	if len(members) < 2 || len(members) > MaxFamilyMembers {
+		return nil, fmt.Errorf("Family compatibility needs 2 to %d members", MaxFamilyMembers)
+	}
+
+	if members[0].Role != FamilyChild {
+		return nil, fmt.Errorf("First member must be the child")
+	}
+
+	family := &FamilyData{language: language}
+	for i, m := range members {
+		if (i > 0 && m.Role == FamilyChild) || m.Role < FamilyChild || m.Role > FamilySibling {
+			return nil, fmt.Errorf("Invalid role of member %d", i)
+		}
+
+		chart := Chart(language, m.BirthTime, m.Location, ziHour, utils.GenderUnknown, 0, 0)
+		d := &familyMemberData{
+			Index:      i,
+			Role:       m.Role,
+			RoleAlias:  texts.GetAlias(texts.AliasFamilyRole, m.Role, language),
+			Animal:     chart.Calendar.Lunar.AnimalSign,
+			DayMaster:  chart.Calendar.Ganzhi.Day.TianGan,
+			DayElement: utils.GanFiveElement(chart.Calendar.Ganzhi.Day.TianGan),
+			Chart:      chart,
+		}
+
+		d.AnimalAlias = texts.GetAlias(texts.AliasAnimal, d.Animal, language)
+		d.DayMasterAlias = texts.GetAlias(texts.AliasGan, d.DayMaster, language)
+		d.DayElementAlias = texts.GetAlias(texts.AliasFiveElement, d.DayElement, language)
+		family.Members = append(family.Members, d)
+	}
+
+	family.calculatePairs()
+	family.calculateAdvices()
+
+	return family, nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -41,6 +41,14 @@ type GanzhiFiveElementsSpec struct {
 	FiveElements      utils.FiveElementsCount `json:"five_elements"`
 	FiveElementsZhi   utils.FiveElementsCount `json:"five_elements_zhi"`
 	FiveElementsTotal utils.FiveElementsCount `json:"five_elements_total"`
+
+	// Total adjusted by transformed combinations
+	FiveElementsAdjusted utils.FiveElementsCount `json:"five_elements_adjusted"`
+
+	// Weighted strengths and deficits
+	Strengths     []*elementStrength `json:"strengths"`
+	Deficits      []int              `json:"deficits"`
+	DeficitsAlias string             `json:"deficits_alias"`
 }
 
 // SoundFiveElements : Sound five-elements
@@ -93,59 +101,9 @@ func GanzhiFiveElements(c *calendar.Calendar) GanzhiFiveElementsSpec {
 
 	// DiZhi & ZhiCang
 	for _, v = range []int{c.Ganzhi.Year.DiZhi, c.Ganzhi.Month.DiZhi, c.Ganzhi.Day.DiZhi, c.Ganzhi.Hour.DiZhi} {
-		switch v {
-		case utils.ZhiZi:
-			ret.FiveElements.Water++
-			ret.FiveElementsZhi.Water++ // 癸
-		case utils.ZhiChou:
-			ret.FiveElements.Earth++
-			ret.FiveElementsZhi.Earth++ // 己
-			ret.FiveElementsZhi.Metal++ // 辛
-			ret.FiveElementsZhi.Water++ // 癸
-		case utils.ZhiYin:
-			ret.FiveElements.Wood++
-			ret.FiveElementsZhi.Wood++  // 甲
-			ret.FiveElementsZhi.Fire++  // 丙
-			ret.FiveElementsZhi.Earth++ // 戊
-		case utils.ZhiMao:
-			ret.FiveElements.Wood++
-			ret.FiveElementsZhi.Wood++ // 乙
-		case utils.ZhiChen:
-			ret.FiveElements.Earth++
-			ret.FiveElementsZhi.Earth++ // 戊
-			ret.FiveElementsZhi.Water++ // 癸
-			ret.FiveElementsZhi.Wood++  // 乙
-		case utils.ZhiSi:
-			ret.FiveElements.Fire++
-			ret.FiveElementsZhi.Fire++  // 丙
-			ret.FiveElementsZhi.Earth++ // 戊
-			ret.FiveElementsZhi.Metal++ // 庚
-		case utils.ZhiWu:
-			ret.FiveElements.Fire++
-			ret.FiveElementsZhi.Fire++  // 丁
-			ret.FiveElementsZhi.Earth++ // 己
-		case utils.ZhiWei:
-			ret.FiveElements.Earth++
-			ret.FiveElementsZhi.Earth++ // 己
-			ret.FiveElementsZhi.Wood++  // 乙
-			ret.FiveElementsZhi.Fire++  // 丁
-		case utils.ZhiShen:
-			ret.FiveElements.Metal++
-			ret.FiveElementsZhi.Metal++ // 庚
-			ret.FiveElementsZhi.Water++ // 壬
-			ret.FiveElementsZhi.Earth++ // 戊
-		case utils.ZhiYou:
-			ret.FiveElements.Metal++
-			ret.FiveElementsZhi.Metal++ // 辛
-		case utils.ZhiXu:
-			ret.FiveElements.Earth++
-			ret.FiveElementsZhi.Earth++ // 戊
-			ret.FiveElementsZhi.Metal++ // 辛
-			ret.FiveElementsZhi.Fire++  // 丁
-		case utils.ZhiHai:
-			ret.FiveElements.Water++
-			ret.FiveElementsZhi.Water++ // 壬
-			ret.FiveElementsZhi.Wood++  // 甲
+		ret.FiveElements.Add(utils.ZhiFiveElement(v), 1)
+		for _, h := range utils.ZhiHiddenGans(v) {
+			ret.FiveElementsZhi.Add(utils.GanFiveElement(h.Gan), 1)
 		}
 	}
 
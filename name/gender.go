@@ -0,0 +1,109 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file gender.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"yixuan_naming/list"
+	"yixuan_naming/unihan"
+	"yixuan_naming/utils"
+)
+
+// characterGender : Gender tag of character, simplified form as fallback
+func characterGender(r rune) int {
+	tag := list.QueryCharacterGender(r)
+	if tag == list.GenderTagNeutral {
+		c, _ := unihan.Query(r)
+		if c != nil {
+			rs, _ := c.QuerySimplifiedPrefer()
+			if rs > 0 && rs != r {
+				tag = list.QueryCharacterGender(rs)
+			}
+		}
+	}
+
+	return tag
+}
+
+// runesGender : Gender tag of rune list, neutral if mixed
+func runesGender(runes []rune) int {
+	var masculine, feminine bool
+	for _, r := range runes {
+		switch characterGender(r) {
+		case list.GenderTagMasculine:
+			masculine = true
+		case list.GenderTagFeminine:
+			feminine = true
+		}
+	}
+
+	if masculine && !feminine {
+		return list.GenderTagMasculine
+	}
+
+	if feminine && !masculine {
+		return list.GenderTagFeminine
+	}
+
+	return list.GenderTagNeutral
+}
+
+// filterGender : Remove characters with opposite gender tag
+func filterGender(runes []rune, gender int) []rune {
+	var (
+		opposite int
+		ret      []rune
+	)
+
+	switch gender {
+	case utils.GenderMale:
+		opposite = list.GenderTagFeminine
+	case utils.GenderFemale:
+		opposite = list.GenderTagMasculine
+	default:
+		return runes
+	}
+
+	for _, r := range runes {
+		if characterGender(r) != opposite {
+			ret = append(ret, r)
+		}
+	}
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,112 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file generation.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// Positions of generation character in given name
+const (
+	// GenerationFirst : Character right after family name, as middle name
+	GenerationFirst = iota
+	// GenerationLast : Last character of given name
+	GenerationLast
+)
+
+// GenerationRune : Character of generation index (1-based) in poem, punctuations ignored
+func GenerationRune(poem []rune, index int) (rune, error) {
+	var chars []rune
+	for _, r := range poem {
+		if unicode.Is(unicode.Han, r) {
+			chars = append(chars, r)
+		}
+	}
+
+	if index < 1 || index > len(chars) {
+		return 0, fmt.Errorf("Generation index %d out of poem (%d characters)", index, len(chars))
+	}
+
+	return chars[index-1], nil
+}
+
+// PinGeneration : Middle name and suffix with generation character of poem pinned at position,
+// position must not be pinned already by other character
+func PinGeneration(poem []rune, index, position int, middle, suffix []rune) ([]rune, []rune, error) {
+	r, err := GenerationRune(poem, index)
+	if err != nil {
+		return middle, suffix, err
+	}
+
+	_pinned := func(runes []rune) bool {
+		return len(runes) > 0 && !(len(runes) == 1 && runes[0] == r)
+	}
+
+	switch position {
+	case GenerationFirst:
+		if _pinned(middle) {
+			return middle, suffix, fmt.Errorf("Middle name already pinned, conflicts with generation character %s", string(r))
+		}
+
+		middle = []rune{r}
+	case GenerationLast:
+		if _pinned(suffix) {
+			return middle, suffix, fmt.Errorf("Suffix already pinned, conflicts with generation character %s", string(r))
+		}
+
+		suffix = []rune{r}
+	default:
+		return middle, suffix, fmt.Errorf("Invalid generation position %d", position)
+	}
+
+	return middle, suffix, nil
+}
+
+// ApplyGeneration : Pin generation character of poem into conditions, position must not be pinned already
+func (c *KirsenConditions) ApplyGeneration() error {
+	var err error
+	if len(c.GenerationPoem) == 0 {
+		return nil
+	}
+
+	c.MiddleNameRunes, c.SuffixNameRunes, err = PinGeneration(c.GenerationPoem, c.GenerationIndex, c.GenerationPosition, c.MiddleNameRunes, c.SuffixNameRunes)
+
+	return err
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
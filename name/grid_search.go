@@ -0,0 +1,241 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file grid_search.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"fmt"
+	"sort"
+
+	"yixuan_naming/list"
+	"yixuan_naming/texts"
+)
+
+const (
+	// DefaultGridCombinations : Default stroke combinations return by grid search
+	DefaultGridCombinations = 20
+
+	// MaxGridCombinations : Maxinum stroke combinations return by grid search
+	MaxGridCombinations = 200
+)
+
+// GridRange : Range of grid number, 0 for unbounded side
+type GridRange struct {
+	Min int
+	Max int
+}
+
+// GridSearchConditions : Target grids of reverse five-grid search
+type GridSearchConditions struct {
+	FamilyNameRunes []rune
+	GivenNameLength int
+
+	TianGe GridRange
+	RenGe  GridRange
+	DiGe   GridRange
+	WaiGe  GridRange
+	ZongGe GridRange
+
+	// MinThreeElementRank : Minimum rank (RankDaXiong - RankDaJi) of three elements, 0 for no filter
+	MinThreeElementRank int
+
+	Gender         int
+	CharacterLevel int
+	StrokeSchool   int
+	QueryNums      int
+}
+
+type gridCombination struct {
+	Strokes               []int  `json:"strokes"`
+	TianGe                int    `json:"tian_ge"`
+	RenGe                 int    `json:"ren_ge"`
+	DiGe                  int    `json:"di_ge"`
+	WaiGe                 int    `json:"wai_ge"`
+	ZongGe                int    `json:"zong_ge"`
+	ThreeElementRank      int    `json:"three_element_rank"`
+	ThreeElementRankAlias string `json:"three_element_rank_alias"`
+	Rank                  int    `json:"rank"`
+}
+
+// GridSearchData : Reverse five-grid search result
+type GridSearchData struct {
+	FamilyStrokes     []int              `json:"family_strokes"`
+	StrokeSchool      int                `json:"stroke_school"`
+	StrokeSchoolAlias string             `json:"stroke_school_alias"`
+	Combinations      []*gridCombination `json:"combinations"`
+	Candidates        map[int][]string   `json:"candidates"`
+	Total             int                `json:"total"`
+}
+
+// Valid : Range with lower bound not above upper bound
+func (r GridRange) Valid() bool {
+	return r.Min <= 0 || r.Max <= 0 || r.Min <= r.Max
+}
+
+func (r GridRange) contains(v int) bool {
+	return (r.Min <= 0 || v >= r.Min) && (r.Max <= 0 || v <= r.Max)
+}
+
+// GridSearch : Given name stroke combinations hitting target grids, with common characters of each stroke
+func GridSearch(language int, c *GridSearchConditions) (*GridSearchData, error) {
+	var (
+		ret        = &GridSearchData{StrokeSchool: c.StrokeSchool}
+		family     = make([]int, 2)
+		candidates = make(map[int][]string)
+	)
+
+	if len(c.FamilyNameRunes) < 1 || len(c.FamilyNameRunes) > 2 {
+		return nil, fmt.Errorf("Family name must be one or two characters")
+	}
+
+	for _, r := range []GridRange{c.TianGe, c.RenGe, c.DiGe, c.WaiGe, c.ZongGe} {
+		if !r.Valid() {
+			return nil, fmt.Errorf("Invalid grid range %d - %d", r.Min, r.Max)
+		}
+	}
+
+	if c.GivenNameLength < 1 || c.GivenNameLength > 3 {
+		c.GivenNameLength = 2
+	}
+
+	if c.QueryNums <= 0 {
+		c.QueryNums = DefaultGridCombinations
+	}
+
+	if c.QueryNums > MaxGridCombinations {
+		c.QueryNums = MaxGridCombinations
+	}
+
+	for i, r := range c.FamilyNameRunes {
+		stroke, err := runeStroke(c.StrokeSchool, r)
+		if err != nil {
+			return nil, err
+		}
+
+		family[i] = stroke
+		ret.FamilyStrokes = append(ret.FamilyStrokes, stroke)
+	}
+
+	ret.StrokeSchoolAlias = texts.GetAlias(texts.AliasStrokeSchool, c.StrokeSchool, language)
+
+	// Candidate characters by stroke, filtered by gender
+	_candidates := func(stroke int) []string {
+		if v, ok := candidates[stroke]; ok {
+			return v
+		}
+
+		var v []string
+		for _, r := range filterGender(list.GetCommonByStroke(c.StrokeSchool, c.CharacterLevel, stroke), c.Gender) {
+			v = append(v, string(r))
+		}
+
+		candidates[stroke] = v
+
+		return v
+	}
+
+	given := make([]int, c.GivenNameLength)
+	for i := range given {
+		given[i] = 1
+	}
+
+	for {
+		tianGe, renGe, diGe, zongGe, waiGe := calcFiveGrids(family, given)
+		if c.TianGe.contains(tianGe) && c.RenGe.contains(renGe) && c.DiGe.contains(diGe) && c.WaiGe.contains(waiGe) && c.ZongGe.contains(zongGe) {
+			three := getRuleThreeElementRank(((tianGe-1)%10)/2*25 + ((renGe-1)%10)/2*5 + ((diGe-1)%10)/2)
+			if three >= c.MinThreeElementRank {
+				comb := &gridCombination{
+					Strokes:          append([]int{}, given...),
+					TianGe:           tianGe,
+					RenGe:            renGe,
+					DiGe:             diGe,
+					WaiGe:            waiGe,
+					ZongGe:           zongGe,
+					ThreeElementRank: three,
+					Rank:             calcRank(family, given),
+				}
+
+				for _, stroke := range given {
+					if len(_candidates(stroke)) == 0 {
+						comb = nil
+						break
+					}
+				}
+
+				if comb != nil {
+					comb.ThreeElementRankAlias = texts.GetAlias(texts.AliasRank, three, language)
+					ret.Combinations = append(ret.Combinations, comb)
+				}
+			}
+		}
+
+		// Next combination
+		i := 0
+		for ; i < len(given); i++ {
+			given[i]++
+			if given[i] <= list.MaxStroke {
+				break
+			}
+
+			given[i] = 1
+		}
+
+		if i == len(given) {
+			break
+		}
+	}
+
+	sort.SliceStable(ret.Combinations, func(i, j int) bool {
+		return ret.Combinations[i].Rank > ret.Combinations[j].Rank
+	})
+
+	ret.Total = len(ret.Combinations)
+	if len(ret.Combinations) > c.QueryNums {
+		ret.Combinations = ret.Combinations[:c.QueryNums]
+	}
+
+	// Candidate characters once per stroke of returned combinations
+	ret.Candidates = make(map[int][]string)
+	for _, comb := range ret.Combinations {
+		for _, stroke := range comb.Strokes {
+			ret.Candidates[stroke] = candidates[stroke]
+		}
+	}
+
+	return ret, nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
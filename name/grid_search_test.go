@@ -0,0 +1,286 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file grid_search_test.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"yixuan_naming/list"
+	"yixuan_naming/unihan"
+)
+
+var (
+	testLibraryOnce sync.Once
+	testLibraryDir  string
+	testLibraryErr  error
+
+	// Fixture characters with total strokes
+	testLibraryStrokes = map[rune]int{
+		'李': 7, '子': 3, '文': 4, '安': 6, '华': 6, '宇': 6,
+		'明': 8, '林': 8, '雨': 8, '涵': 11, '静': 14, '徽': 17,
+	}
+)
+
+// loadTestLibrary : Unihan and common character list of fixture characters, loaded once
+func loadTestLibrary(t *testing.T) {
+	testLibraryOnce.Do(func() {
+		dir, err := ioutil.TempDir("", "naming")
+		if err != nil {
+			testLibraryErr = err
+			return
+		}
+
+		testLibraryDir = dir
+
+		for _, sub := range []string{"unihan", "list"} {
+			if err = os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+				testLibraryErr = err
+				return
+			}
+		}
+
+		var strokes, common []byte
+		for r, s := range testLibraryStrokes {
+			strokes = append(strokes, fmt.Sprintf("U+%04X\tkTotalStrokes\t%d\n", r, s)...)
+			common = append(common, fmt.Sprintf("%d\n", r)...)
+		}
+
+		files := map[string][]byte{
+			"unihan/Unihan_DictionaryIndices.txt":    nil,
+			"unihan/Unihan_DictionaryLikeData.txt":   strokes,
+			"unihan/Unihan_IRGSources.txt":           nil,
+			"unihan/Unihan_NumericValues.txt":        nil,
+			"unihan/Unihan_OtherMappings.txt":        nil,
+			"unihan/Unihan_RadicalStrokeCounts.txt":  nil,
+			"unihan/Unihan_Readings.txt":             nil,
+			"unihan/Unihan_Variants.txt":             nil,
+			"list/CommonChineseNameCharactersL1.txt": common,
+		}
+
+		for name, content := range files {
+			if err = ioutil.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+				testLibraryErr = err
+				return
+			}
+		}
+
+		if _, _, err = unihan.LoadUnihanLibraries(dir); err != nil {
+			testLibraryErr = err
+			return
+		}
+
+		if _, err = list.LoadCommonL1(dir); err != nil {
+			testLibraryErr = err
+			return
+		}
+
+		list.PrepareCommonCharacters()
+	})
+
+	if testLibraryErr != nil {
+		t.Fatal(testLibraryErr)
+	}
+}
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testLibraryDir != "" {
+		os.RemoveAll(testLibraryDir)
+	}
+
+	os.Exit(code)
+}
+
+func gridConditions(family string, mod func(c *GridSearchConditions)) *GridSearchConditions {
+	c := &GridSearchConditions{
+		FamilyNameRunes: []rune(family),
+		GivenNameLength: 2,
+		CharacterLevel:  1,
+		StrokeSchool:    list.StrokeSchoolSimplified,
+		QueryNums:       MaxGridCombinations,
+	}
+
+	mod(c)
+
+	return c
+}
+
+// checkGridResult : Combinations inside ranges, candidates listed once per returned stroke
+func checkGridResult(t *testing.T, c *GridSearchConditions, ret *GridSearchData) {
+	strokes := make(map[int]bool)
+	for _, comb := range ret.Combinations {
+		for _, g := range []struct {
+			name  string
+			r     GridRange
+			value int
+		}{
+			{"tian_ge", c.TianGe, comb.TianGe},
+			{"ren_ge", c.RenGe, comb.RenGe},
+			{"di_ge", c.DiGe, comb.DiGe},
+			{"wai_ge", c.WaiGe, comb.WaiGe},
+			{"zong_ge", c.ZongGe, comb.ZongGe},
+		} {
+			if !g.r.contains(g.value) {
+				t.Errorf("Combination %v has %s %d out of %d - %d", comb.Strokes, g.name, g.value, g.r.Min, g.r.Max)
+			}
+		}
+
+		if comb.ThreeElementRank < c.MinThreeElementRank {
+			t.Errorf("Combination %v has three element rank %d below %d", comb.Strokes, comb.ThreeElementRank, c.MinThreeElementRank)
+		}
+
+		for _, s := range comb.Strokes {
+			strokes[s] = true
+		}
+	}
+
+	if len(ret.Candidates) != len(strokes) {
+		t.Errorf("Candidates of %d strokes, %d strokes in combinations", len(ret.Candidates), len(strokes))
+	}
+
+	for s := range strokes {
+		seen := make(map[string]bool)
+		for _, v := range ret.Candidates[s] {
+			if seen[v] {
+				t.Errorf("Candidate %s listed twice for stroke %d", v, s)
+			}
+
+			seen[v] = true
+			if testLibraryStrokes[[]rune(v)[0]] != s {
+				t.Errorf("Candidate %s listed for stroke %d", v, s)
+			}
+		}
+
+		if len(seen) == 0 {
+			t.Errorf("No candidates for stroke %d", s)
+		}
+	}
+}
+
+// TestGridSearchExact : 李 (7) with RenGe 24 and ZongGe 32 is only 17 + 8
+func TestGridSearchExact(t *testing.T) {
+	loadTestLibrary(t)
+	c := gridConditions("李", func(c *GridSearchConditions) {
+		c.RenGe = GridRange{Min: 24, Max: 24}
+		c.ZongGe = GridRange{Min: 32, Max: 32}
+	})
+
+	ret, err := GridSearch(0, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ret.Total != 1 || len(ret.Combinations) != 1 {
+		t.Fatalf("%d combinations, 1 expected", ret.Total)
+	}
+
+	comb := ret.Combinations[0]
+	if fmt.Sprint(comb.Strokes) != "[17 8]" || comb.TianGe != 8 || comb.RenGe != 24 || comb.DiGe != 25 || comb.WaiGe != 9 || comb.ZongGe != 32 {
+		t.Errorf("Combination %+v, strokes [17 8] with grids 8 24 25 9 32 expected", comb)
+	}
+
+	candidates := append([]string{}, ret.Candidates[8]...)
+	sort.Strings(candidates)
+	if fmt.Sprint(ret.FamilyStrokes) != "[7]" || fmt.Sprint(ret.Candidates[17]) != "[徽]" || fmt.Sprint(candidates) != fmt.Sprint([]string{"明", "林", "雨"}) {
+		t.Errorf("Family strokes %v, candidates %v", ret.FamilyStrokes, ret.Candidates)
+	}
+
+	checkGridResult(t, c, ret)
+}
+
+// TestGridSearchRange : Ranges and three element filter hold for all combinations
+func TestGridSearchRange(t *testing.T) {
+	loadTestLibrary(t)
+	all, err := GridSearch(0, gridConditions("李", func(c *GridSearchConditions) {
+		c.RenGe = GridRange{Min: 10, Max: 20}
+	}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := gridConditions("李", func(c *GridSearchConditions) {
+		c.RenGe = GridRange{Min: 10, Max: 20}
+		c.MinThreeElementRank = RankDaJi
+	})
+
+	ret, err := GridSearch(0, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ret.Total == 0 || ret.Total >= all.Total {
+		t.Errorf("%d combinations of three element rank DaJi, %d without filter", ret.Total, all.Total)
+	}
+
+	checkGridResult(t, c, ret)
+
+	// Truncated results list candidates of returned combinations only
+	c.QueryNums = 1
+	ret, err = GridSearch(0, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ret.Combinations) != 1 {
+		t.Fatalf("%d combinations returned, 1 expected", len(ret.Combinations))
+	}
+
+	checkGridResult(t, c, ret)
+}
+
+// TestGridSearchInvalid : Reversed range and long family name rejected
+func TestGridSearchInvalid(t *testing.T) {
+	loadTestLibrary(t)
+	if _, err := GridSearch(0, gridConditions("李", func(c *GridSearchConditions) {
+		c.RenGe = GridRange{Min: 20, Max: 10}
+	})); err == nil {
+		t.Error("Reversed range accepted")
+	}
+
+	if _, err := GridSearch(0, gridConditions("李明华", func(c *GridSearchConditions) {})); err == nil {
+		t.Error("Three character family name accepted")
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
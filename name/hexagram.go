@@ -0,0 +1,169 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file hexagram.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"yixuan_naming/texts"
+	"yixuan_naming/utils"
+)
+
+// Lines of hexagram
+const (
+	// HexagramLines : Lines of one hexagram
+	HexagramLines = 6
+	// TrigramLines : Lines of one trigram
+	TrigramLines = 3
+)
+
+type trigram struct {
+	Trigram          int    `json:"trigram"`
+	Alias            string `json:"alias"`
+	NatureAlias      string `json:"nature_alias"`
+	FiveElement      int    `json:"five_element"`
+	FiveElementAlias string `json:"five_element_alias"`
+}
+
+type hexagram struct {
+	Order       int     `json:"order"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Upper       trigram `json:"upper"`
+	Lower       trigram `json:"lower"`
+}
+
+type hexagrams struct {
+	UpperStrokes   int       `json:"upper_strokes"`
+	LowerStrokes   int       `json:"lower_strokes"`
+	MovingLine     int       `json:"moving_line"`
+	MovingLineText string    `json:"moving_line_text"`
+	Main           *hexagram `json:"main"`
+	Mutual         *hexagram `json:"mutual"`
+	Changed        *hexagram `json:"changed"`
+	Body           int       `json:"body"`
+	BodyAlias      string    `json:"body_alias"`
+	Use            int       `json:"use"`
+	UseAlias       string    `json:"use_alias"`
+	Relation       int       `json:"relation"`
+	RelationAlias  string    `json:"relation_alias"`
+	Rank           int       `json:"rank"`
+	RankAlias      string    `json:"rank_alias"`
+}
+
+// Ranks of body trigram against use trigram, by five-element relation
+var bodyUseRanks = map[int]int{
+	utils.FiveElementBirthed: RankDaJi,
+	utils.FiveElementEqual:   RankJi,
+	utils.FiveElementKill:    RankJi,
+	utils.FiveElementBirth:   RankXiong,
+	utils.FiveElementKilled:  RankDaXiong,
+}
+
+func newTrigram(t int, language int) trigram {
+	element := utils.EightTrigramFiveElement[t]
+
+	return trigram{
+		Trigram:          t,
+		Alias:            texts.GetAlias(texts.AliasEightTrigram, t, language),
+		NatureAlias:      texts.GetAlias(texts.AliasEightTrigramNature, t, language),
+		FiveElement:      element,
+		FiveElementAlias: texts.GetAlias(texts.AliasFiveElement, element, language),
+	}
+}
+
+func newHexagram(h utils.Hexagram, language int) *hexagram {
+	order := h.Order()
+
+	return &hexagram{
+		Order:       order,
+		Name:        texts.GetMessage(texts.MessageHexagramName, order-1, language),
+		Description: texts.GetMessage(texts.MessageHexagramDescription, order-1, language),
+		Upper:       newTrigram(h.Upper, language),
+		Lower:       newTrigram(h.Lower, language),
+	}
+}
+
+// calculateHexagrams : Main hexagram of family name strokes over given name strokes, with mutual and changed ones
+func (rank *RankData) calculateHexagrams() {
+	var (
+		n  = rank.Name.gridName()
+		hs = &rank.Hexagrams
+	)
+
+	hs.UpperStrokes, hs.LowerStrokes = 0, 0
+	for _, s := range n.FamilyName.Strokes {
+		hs.UpperStrokes += s
+	}
+
+	for _, s := range n.MiddleName.Strokes {
+		hs.LowerStrokes += s
+	}
+
+	for _, s := range n.GivenName.Strokes {
+		hs.LowerStrokes += s
+	}
+
+	h := utils.Hexagram{
+		Upper: utils.XiantianTrigrams[hs.UpperStrokes%len(utils.XiantianTrigrams)],
+		Lower: utils.XiantianTrigrams[hs.LowerStrokes%len(utils.XiantianTrigrams)],
+	}
+
+	hs.MovingLine = (hs.UpperStrokes + hs.LowerStrokes) % HexagramLines
+	if hs.MovingLine == 0 {
+		hs.MovingLine = HexagramLines
+	}
+
+	hs.Main = newHexagram(h, rank.language)
+	hs.Mutual = newHexagram(h.Mutual(), rank.language)
+	hs.Changed = newHexagram(h.Changed(hs.MovingLine), rank.language)
+	hs.MovingLineText = texts.GetMessage(texts.MessageHexagramLine, (hs.Main.Order-1)*HexagramLines+hs.MovingLine-1, rank.language)
+
+	// Trigram with moving line is the use, the other is the body
+	if hs.MovingLine > TrigramLines {
+		hs.Body, hs.Use = h.Lower, h.Upper
+	} else {
+		hs.Body, hs.Use = h.Upper, h.Lower
+	}
+
+	hs.BodyAlias = texts.GetAlias(texts.AliasEightTrigram, hs.Body, rank.language)
+	hs.UseAlias = texts.GetAlias(texts.AliasEightTrigram, hs.Use, rank.language)
+	hs.Relation = utils.CompareFiveElements(utils.EightTrigramFiveElement[hs.Body], utils.EightTrigramFiveElement[hs.Use])
+	hs.RelationAlias = texts.GetAlias(texts.AliasFiveElementRelation, hs.Relation, rank.language)
+	hs.Rank = bodyUseRanks[hs.Relation]
+	hs.RankAlias = texts.GetAlias(texts.AliasRank, hs.Rank, rank.language)
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -32,7 +32,8 @@ package name
 
 import (
 	"fmt"
-	"math"
+	"sort"
+	"sync"
 	"yixuan_naming/texts"
 	"yixuan_naming/utils"
 
@@ -47,12 +48,25 @@ const (
 
 	// MaxNames : Maxinum names return by kirsen
 	MaxNames = 10000
+
+	// MaxTripleTables : Maxinum family names of cached three-character rank tables
+	MaxTripleTables = 64
 )
 
 var (
-	strokeTable [][][]int
+	strokeTable      [][][]int
+	tripleTables     = make(map[int]*tripleTable)
+	tripleTablesTick uint64
+	tripleTablesLock sync.Mutex
 )
 
+// tripleTable : Cached three-character rank table of one family name, ready when done closed
+type tripleTable struct {
+	ranks [][]uint16
+	done  chan struct{}
+	used  uint64
+}
+
 // KirsenConditions : Conditions of name list generation
 type KirsenConditions struct {
 	FamilyNameRunes []rune
@@ -67,6 +81,23 @@ type KirsenConditions struct {
 	MaxRank         int
 	MinRank         int
 	CharacterLevel  int
+
+	// Generation poem (ZiBei)
+	GenerationPoem     []rune
+	GenerationIndex    int
+	GenerationPosition int
+
+	// MinPhonetics : Minimum phonetics score, 0 for no filter
+	MinPhonetics int
+
+	// ZiHour : Zi hour convention of day pillar
+	ZiHour int
+
+	// ExcludeOminous : Exclude candidate characters with ominous radicals of zodiac
+	ExcludeOminous bool
+
+	// StrokeSchool : Stroke counting school
+	StrokeSchool int
 }
 
 // Traditionalize : Traditionalize conditions
@@ -129,11 +160,15 @@ type KirsenData struct {
 	SoundFiveElements  SoundFiveElements      `json:"sound_five_elements"`
 	EightCharacters    eightCharacters        `json:"eight_characters"`
 	Animal             animal                 `json:"animal"`
+	StrokeSchool       int                    `json:"stroke_school"`
+	StrokeSchoolAlias  string                 `json:"stroke_school_alias"`
 	Total              int                    `json:"total"`
 }
 
 func (kirsen *KirsenData) calculateGanzhi() {
 	kirsen.GanzhiFiveElements = GanzhiFiveElements(kirsen.Calendar)
+	kirsen.GanzhiFiveElements.adjust(&kirsen.EightCharacters)
+	kirsen.GanzhiFiveElements.weigh(&kirsen.EightCharacters, kirsen.language)
 }
 
 func (kirsen *KirsenData) calculateSounds() {
@@ -154,6 +189,8 @@ func (kirsen *KirsenData) calculateEightCharacters() {
 	kirsen.EightCharacters.Day = &kirsen.Calendar.Ganzhi.Day
 	kirsen.EightCharacters.Hour = &kirsen.Calendar.Ganzhi.Hour
 	kirsen.EightCharacters.complete()
+	kirsen.EightCharacters.calculateTenGods(kirsen.language)
+	kirsen.EightCharacters.localize(kirsen.language)
 }
 
 // CalcCommonStrokes : Get strokes of common characters
@@ -175,10 +212,7 @@ func CalcCommonStrokes(level int) {
 	for r := range charList {
 		c, _ = unihan.Query(r)
 		if c != nil {
-			stroke = list.QueryStrokeSpecial(r)
-			if stroke <= 0 {
-				stroke = c.QueryStrokePrefer()
-			}
+			stroke = list.GetStrokeCounter(list.StrokeSchoolDefault).Count(c)
 			strokeCounter[stroke]++
 		}
 	}
@@ -186,74 +220,97 @@ func CalcCommonStrokes(level int) {
 	return
 }
 
-func calcRank(f0, f1, g0, g1 int) int {
-	var (
-		tianGe, diGe, renGe, zongGe, waiGe                int
-		tianCai, diCai, renCai                            int
-		scoreTian, scoreDi, scoreRen, scoreZong, scoreWai int
-		scoreThreeElement                                 int
-		rank                                              int
-	)
+// calcFiveGrids : Five grids of family name strokes (1 or 2) and given name strokes
+func calcFiveGrids(family, given []int) (tianGe, renGe, diGe, zongGe, waiGe int) {
+	var f0, f1 int
+	if len(family) > 0 {
+		f0 = family[0]
+	}
 
-	_g81 := func(i int) int {
-		if i > 81 {
-			return i - 80
-		}
-		return i
+	if len(family) > 1 {
+		f1 = family[1]
 	}
 
 	if f1 > 0 {
 		tianGe = f0 + f1
-		if g1 > 0 {
-			renGe = f1 + g0
-			waiGe = f0 + g1
-		} else {
-			renGe = f1
-			waiGe = f0 + 1
-		}
+		renGe = f1
 	} else {
 		tianGe = f0 + 1
-		if g1 > 0 {
-			renGe = f0 + g0
-			waiGe = 1 + g1
-		} else {
-			renGe = f0
-			waiGe = 2
-		}
+		renGe = f0
 	}
 
-	if g1 > 0 {
-		diGe = g0 + g1
-	} else {
-		diGe = g0 + 1
+	if len(given) > 0 {
+		renGe += given[0]
+	}
+
+	for _, g := range given {
+		diGe += g
 	}
 
-	zongGe = f0 + f1 + g0 + g1
+	zongGe = f0 + f1 + diGe
 
+	// Single characters take one imaginary stroke
+	waiGe = zongGe - renGe
+	if f1 == 0 {
+		waiGe++
+	}
+
+	if len(given) <= 1 {
+		diGe++
+		waiGe++
+	}
+
+	return
+}
+
+func calcRank(family, given []int) int {
+	var (
+		tianGe, renGe, diGe, zongGe, waiGe int
+		tianCai, diCai, renCai             int
+	)
+
+	_g81 := func(i int) int {
+		if i > 81 {
+			return i - 80
+		}
+		return i
+	}
+
+	tianGe, renGe, diGe, zongGe, waiGe = calcFiveGrids(family, given)
 	tianCai = ((tianGe - 1) % 10) / 2
 	diCai = ((diGe - 1) % 10) / 2
 	renCai = ((renGe - 1) % 10) / 2
 
-	scores := []int{0, 0, 25, 50, 75, 100}
-	scoreTian = scores[getRule81Rank(_g81(tianGe))]
-	scoreDi = scores[getRule81Rank(_g81(diGe))]
-	scoreRen = scores[getRule81Rank(_g81(renGe))]
-	scoreZong = scores[getRule81Rank(_g81(zongGe))]
-	scoreWai = scores[getRule81Rank(_g81(waiGe))]
-	scoreThreeElement = scores[getRuleThreeElementRank(tianCai*25+renCai*5+diCai)]
-
-	rank = int(
-		math.Ceil(float64(scoreRen)*0.21) +
-			math.Ceil(float64(scoreZong)*0.2) +
-			math.Ceil(float64(scoreTian)*0.13) +
-			math.Ceil(float64(scoreDi)*0.13) +
-			math.Ceil(float64(scoreWai)*0.13) +
-			math.Ceil(float64(scoreThreeElement)*0.20))
-	if rank > 100 {
-		rank = 100
-	}
-
-	return rank
+	return scoringProfile.fiveRulesRank(
+		getRule81Rank(_g81(tianGe)),
+		getRule81Rank(_g81(renGe)),
+		getRule81Rank(_g81(diGe)),
+		getRule81Rank(_g81(zongGe)),
+		getRule81Rank(_g81(waiGe)),
+		getRuleThreeElementRank(tianCai*25+renCai*5+diCai))
+}
+
+// strokeKey : Index of one or two strokes in stroke table
+func strokeKey(s0, s1 int) int {
+	return s1*(list.MaxStroke+1) + s0
+}
+
+// Packed triples must fit into 16 bits, fails to compile if MaxStroke ^ 3 exceeds 65536
+const _ = uint16(list.MaxStroke*list.MaxStroke*list.MaxStroke - 1)
+
+// packTriple : Three given name strokes (1 - MaxStroke) packed into 16 bits
+func packTriple(g0, g1, g2 int) uint16 {
+	return uint16(((g2-1)*list.MaxStroke+(g1-1))*list.MaxStroke + (g0 - 1))
+}
+
+func unpackTriple(v uint16) (g0, g1, g2 int) {
+	i := int(v)
+	g0 = i%list.MaxStroke + 1
+	i /= list.MaxStroke
+	g1 = i%list.MaxStroke + 1
+	g2 = i/list.MaxStroke + 1
+
+	return
 }
 
 // FillRankTable : Calculate rank scores and fill into table
@@ -263,6 +320,7 @@ func FillRankTable() error {
 		givenNameStroke0, givenNameStroke1   int
 		familyNameStroke, givenNameStroke    int
 		rank                                 int
+		given                                []int
 	)
 
 	if strokeTable == nil {
@@ -271,23 +329,33 @@ func FillRankTable() error {
 
 	for familyNameStroke0 = 1; familyNameStroke0 <= list.MaxStroke; familyNameStroke0++ {
 		for familyNameStroke1 = 0; familyNameStroke1 <= list.MaxStroke; familyNameStroke1++ {
-			familyNameStroke = familyNameStroke1*list.MaxStroke + familyNameStroke0
+			familyNameStroke = strokeKey(familyNameStroke0, familyNameStroke1)
 			for givenNameStroke0 = 1; givenNameStroke0 <= list.MaxStroke; givenNameStroke0++ {
 				for givenNameStroke1 = 0; givenNameStroke1 <= list.MaxStroke; givenNameStroke1++ {
-					givenNameStroke = givenNameStroke1*list.MaxStroke + givenNameStroke0
-					rank = calcRank(familyNameStroke0, familyNameStroke1, givenNameStroke0, givenNameStroke1)
+					givenNameStroke = strokeKey(givenNameStroke0, givenNameStroke1)
+					given = []int{givenNameStroke0, givenNameStroke1}
+					if givenNameStroke1 == 0 {
+						given = given[:1]
+					}
+
+					rank = calcRank([]int{familyNameStroke0, familyNameStroke1}, given)
 					strokeTable[familyNameStroke][rank] = append(strokeTable[familyNameStroke][rank], givenNameStroke)
 				}
 			}
 		}
 	}
 
+	// Triple tables depend on scoring profile
+	tripleTablesLock.Lock()
+	tripleTables = make(map[int]*tripleTable)
+	tripleTablesLock.Unlock()
+
 	return nil
 }
 
-// GetRanksFromTable : Get ranks from table
+// GetRanksFromTable : Get ranks from table, given name strokes encoded as g1 * (MaxStroke + 1) + g0
 func GetRanksFromTable(familyNameStroke0, familyNameStroke1 int) [][]int {
-	familyNameStroke := familyNameStroke1*list.MaxStroke + familyNameStroke0
+	familyNameStroke := strokeKey(familyNameStroke0, familyNameStroke1)
 	if familyNameStroke < len(strokeTable) {
 		return strokeTable[familyNameStroke]
 	}
@@ -295,6 +363,77 @@ func GetRanksFromTable(familyNameStroke0, familyNameStroke1 int) [][]int {
 	return nil
 }
 
+// GetTripleRanksFromTable : Get ranks of three-character given names, built on demand per family name
+func GetTripleRanksFromTable(familyNameStroke0, familyNameStroke1 int) [][]uint16 {
+	familyNameStroke := strokeKey(familyNameStroke0, familyNameStroke1)
+	if familyNameStroke >= len(strokeTable) {
+		return nil
+	}
+
+	tripleTablesLock.Lock()
+	tripleTablesTick++
+	if t, ok := tripleTables[familyNameStroke]; ok {
+		// Cached or being built by another request
+		t.used = tripleTablesTick
+		tripleTablesLock.Unlock()
+		<-t.done
+
+		return t.ranks
+	}
+
+	for len(tripleTables) >= MaxTripleTables {
+		// Drop least recently used
+		oldest := -1
+		for k, v := range tripleTables {
+			if oldest < 0 || v.used < tripleTables[oldest].used {
+				oldest = k
+			}
+		}
+
+		delete(tripleTables, oldest)
+	}
+
+	t := &tripleTable{
+		done: make(chan struct{}),
+		used: tripleTablesTick,
+	}
+	tripleTables[familyNameStroke] = t
+	tripleTablesLock.Unlock()
+
+	// Build outside lock, concurrent requests of same family name wait on done
+	t.ranks = buildTripleRanks(familyNameStroke0, familyNameStroke1)
+	close(t.done)
+
+	return t.ranks
+}
+
+// buildTripleRanks : Rank every three-character stroke combination of family name
+func buildTripleRanks(familyNameStroke0, familyNameStroke1 int) [][]uint16 {
+	family := []int{familyNameStroke0, familyNameStroke1}
+	given := make([]int, 3)
+	ranks := make([][]uint16, MaxRank+1)
+	for given[0] = 1; given[0] <= list.MaxStroke; given[0]++ {
+		for given[1] = 1; given[1] <= list.MaxStroke; given[1]++ {
+			for given[2] = 1; given[2] <= list.MaxStroke; given[2]++ {
+				rank := calcRank(family, given)
+				ranks[rank] = append(ranks[rank], packTriple(given[0], given[1], given[2]))
+			}
+		}
+	}
+
+	return ranks
+}
+
+// runeStroke : Stroke of character in counted form of school
+func runeStroke(school int, r rune) (int, error) {
+	stroke := list.QuerySchoolStroke(school, r)
+	if stroke <= 0 {
+		return 0, fmt.Errorf("Unknown strokes of character %s", string(r))
+	}
+
+	return stroke, nil
+}
+
 // Rune to string
 func kirsenSingle(list []rune) [][]rune {
 	var ret [][]rune
@@ -325,39 +464,63 @@ func kirsenDouble(list1, list2 []rune) [][]rune {
 	return ret
 }
 
-func kirsenTriple(list1, list2, list3 []rune) [][]rune {
-	return nil
+func kirsenTriple(list1, list2, list3 []rune, limit int) [][]rune {
+	var ret [][]rune
+	for _, r1 := range list1 {
+		c1, _ := unihan.Query(r1)
+		if c1 != nil {
+			for _, r2 := range list2 {
+				c2, _ := unihan.Query(r2)
+				if c2 != nil {
+					for _, r3 := range list3 {
+						c3, _ := unihan.Query(r3)
+						if c3 != nil {
+							ret = append(ret, []rune{c1.Unicode, c2.Unicode, c3.Unicode})
+							if limit > 0 && len(ret) >= limit {
+								return ret
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return ret
 }
 
 // Kirsen : Fetch name list
 func Kirsen(language int, c *KirsenConditions, birthTime int64, loc utils.Location) (*KirsenData, error) {
 	var (
-		h              *unihan.HanCharacter
 		err            error
-		f0, f1, g0, g1 int
-		g, p, s        int
+		f0, f1         int
+		g              int
 		rank           int
 		sList          [][]int
-		cg0, cg1       []rune
+		tList          [][]uint16
 		givenNameRunes [][]rune
 		total          int
 		topRank        int
 		name           *Name
 		nameList       []*Name
-		kirsen         = &KirsenData{}
+		candidates     = make(map[int][]rune)
+		kirsen         = &KirsenData{language: language}
 	)
 
 	// Calendar
-	kirsen.Calendar = calendar.New(birthTime, loc)
+	kirsen.Calendar = calendar.New(birthTime, loc, c.ZiHour)
 	kirsen.Calendar.Ganzhi.YearString = kirsen.Calendar.Ganzhi.Year.String(kirsen.language)
 	kirsen.Calendar.Ganzhi.MonthString = kirsen.Calendar.Ganzhi.Month.String(kirsen.language)
 	kirsen.Calendar.Ganzhi.DayString = kirsen.Calendar.Ganzhi.Day.String(kirsen.language)
 	kirsen.Calendar.Ganzhi.HourString = kirsen.Calendar.Ganzhi.Hour.String(kirsen.language)
+	kirsen.Calendar.Ganzhi.ZiHourString = texts.GetAlias(texts.AliasZiHour, kirsen.Calendar.Ganzhi.ZiHour, kirsen.language)
 
+	kirsen.calculateEightCharacters()
 	kirsen.calculateGanzhi()
 	kirsen.calculateSounds()
 	kirsen.calculateAnimal()
-	kirsen.calculateEightCharacters()
+	kirsen.StrokeSchool = c.StrokeSchool
+	kirsen.StrokeSchoolAlias = texts.GetAlias(texts.AliasStrokeSchool, c.StrokeSchool, kirsen.language)
 
 	// Max character level = 2
 	if c.CharacterLevel != 2 {
@@ -368,62 +531,141 @@ func Kirsen(language int, c *KirsenConditions, birthTime int64, loc utils.Locati
 		c.QueryNums = MaxNames
 	}
 
-	// Hyphenated name
-	if len(c.FamilyNameRunes) > 1 {
-		h, err = unihan.Query(c.FamilyNameRunes[1])
-		if err == nil {
-			f1 = list.QueryStrokeSpecial(c.FamilyNameRunes[1])
-			if f1 <= 0 {
-				f1 = h.QueryStrokePrefer()
-			}
-		} else {
+	if c.GivenNameLength < 1 || c.GivenNameLength > 3 {
+		c.GivenNameLength = 2
+	}
+
+	// Family name strokes, hyphenated name for two
+	for i, r := range c.FamilyNameRunes {
+		stroke, err := runeStroke(c.StrokeSchool, r)
+		if err != nil {
 			return nil, err
 		}
+
+		switch i {
+		case 0:
+			f0 = stroke
+		case 1:
+			f1 = stroke
+		}
 	}
 
-	if len(c.FamilyNameRunes) > 0 {
-		h, err = unihan.Query(c.FamilyNameRunes[0])
-		if err == nil {
-			f0 = list.QueryStrokeSpecial(c.FamilyNameRunes[0])
-			if f0 <= 0 {
-				f0 = h.QueryStrokePrefer()
-			}
-		} else {
+	// Fixed characters of given name : middle (generation) name, prefix then suffix
+	pins := make([]rune, c.GivenNameLength)
+	_pin := func(i int, r rune) error {
+		if i < 0 || i >= len(pins) {
+			return fmt.Errorf("Too many fixed characters for given name length %d", c.GivenNameLength)
+		}
+
+		if pins[i] != 0 && pins[i] != r {
+			return fmt.Errorf("Conflict fixed characters at position %d", i+1)
+		}
+
+		pins[i] = r
+
+		return nil
+	}
+
+	m := len(c.MiddleNameRunes)
+	for i, r := range c.MiddleNameRunes {
+		if err = _pin(i, r); err != nil {
 			return nil, err
 		}
 	}
 
-	if len(c.PrefixNameRunes) > 0 {
-		h, err = unihan.Query(c.PrefixNameRunes[0])
-		if err == nil {
-			p = list.QueryStrokeSpecial(c.PrefixNameRunes[0])
-			if p <= 0 {
-				p = h.QueryStrokePrefer()
-			}
-		} else {
+	for i, r := range c.PrefixNameRunes {
+		if err = _pin(m+i, r); err != nil {
 			return nil, err
 		}
 	}
 
-	if len(c.SuffixNameRunes) > 0 {
-		h, err = unihan.Query(c.SuffixNameRunes[0])
-		if err == nil {
-			s = list.QueryStrokeSpecial(c.SuffixNameRunes[0])
-			if s <= 0 {
-				s = h.QueryStrokePrefer()
-			}
-		} else {
+	for i, r := range c.SuffixNameRunes {
+		if err = _pin(len(pins)-len(c.SuffixNameRunes)+i, r); err != nil {
 			return nil, err
 		}
 	}
 
-	// Fetch table
-	sList = GetRanksFromTable(f0, f1)
-	for rank = MaxRank; rank > 0; rank-- {
-		if sList[rank] == nil {
+	pinStrokes := make([]int, len(pins))
+	free := -1
+	for i, r := range pins {
+		if r == 0 {
+			if free < 0 {
+				free = i
+			}
+
 			continue
 		}
 
+		pinStrokes[i], err = runeStroke(c.StrokeSchool, r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Candidate characters by stroke, filtered by gender
+	_candidates := func(stroke int) []rune {
+		if v, ok := candidates[stroke]; ok {
+			return v
+		}
+
+		v := filterGender(list.GetCommonByStroke(c.StrokeSchool, c.CharacterLevel, stroke), c.Gender)
+		if c.ExcludeOminous {
+			v = filterAnimal(v, kirsen.Calendar.Lunar.AnimalSign)
+		}
+
+		// Characters filling five-element deficits first, before any cutoff
+		v = append([]rune{}, v...)
+		scores := make(map[rune]int, len(v))
+		for _, r := range v {
+			scores[r] = kirsen.GanzhiFiveElements.fillScore(r)
+		}
+
+		sort.SliceStable(v, func(i, j int) bool {
+			return scores[v[i]] > scores[v[j]]
+		})
+
+		candidates[stroke] = v
+
+		return v
+	}
+
+	// Number of packed given name strokes of rank
+	_count := func(rank int) int {
+		if c.GivenNameLength == 3 {
+			return len(tList[rank])
+		}
+
+		return len(sList[rank])
+	}
+
+	// Given name strokes at index i of rank, unpacked into buf, nil if length not matched
+	_strokes := func(rank, i int, buf []int) []int {
+		buf = buf[:0]
+		switch c.GivenNameLength {
+		case 3:
+			g0, g1, g2 := unpackTriple(tList[rank][i])
+			return append(buf, g0, g1, g2)
+		default:
+			g = sList[rank][i]
+			g0, g1 := g%(list.MaxStroke+1), g/(list.MaxStroke+1)
+			if g1 == 0 && c.GivenNameLength == 1 {
+				return append(buf, g0)
+			} else if g1 > 0 && c.GivenNameLength == 2 {
+				return append(buf, g0, g1)
+			}
+		}
+
+		return nil
+	}
+
+	// Fetch table
+	if c.GivenNameLength == 3 {
+		tList = GetTripleRanksFromTable(f0, f1)
+	} else {
+		sList = GetRanksFromTable(f0, f1)
+	}
+
+	for rank = MaxRank; rank > 0 && total < MaxNames; rank-- {
 		if c.QueryNums == 0 {
 			// Unlimited query number
 			if c.MaxRank > c.MinRank && c.MinRank > 0 {
@@ -437,70 +679,78 @@ func Kirsen(language int, c *KirsenConditions, birthTime int64, loc utils.Locati
 				}
 			} else {
 				// Top rank
-				if rank < topRank {
+				if topRank > 0 && rank < topRank {
 					break
 				}
 			}
 		}
 
-		for _, g = range sList[rank] {
-			g0 = g % (list.MaxStroke)
-			g1 = g / (list.MaxStroke)
+		// Names of same rank, ordered by how they fill five-element deficits
+		var rankNames []*Name
+		buf := make([]int, 0, 3)
+		for j, n := 0, _count(rank); j < n; j++ {
+			gs := _strokes(rank, j, buf)
+			if gs == nil {
+				continue
+			}
 
-			if g0 > 0 {
-				if p > 0 && g0 != p {
-					continue
-				}
+			cgs := make([][]rune, len(gs))
+			for i, stroke := range gs {
+				if pins[i] != 0 {
+					if pinStrokes[i] != stroke {
+						cgs = nil
+						break
+					}
 
-				if c.CharacterLevel == 2 {
-					cg0 = list.GetCommonL2ByStrokeTraditional(g0)
+					cgs[i] = pins[i : i+1]
 				} else {
-					cg0 = list.GetCommonL1ByStrokeTraditional(g0)
+					cgs[i] = _candidates(stroke)
 				}
+			}
 
-				if cg0 != nil && len(cg0) > 0 {
-					if g1 > 0 && c.GivenNameLength > 1 {
-						// Double
-						if s > 0 && g1 != s {
-							continue
-						}
+			if cgs == nil {
+				continue
+			}
 
-						if c.CharacterLevel == 2 {
-							cg1 = list.GetCommonL2ByStrokeTraditional(g1)
-						} else {
-							cg1 = list.GetCommonL1ByStrokeTraditional(g1)
-						}
+			switch len(cgs) {
+			case 1:
+				givenNameRunes = kirsenSingle(cgs[0])
+			case 2:
+				givenNameRunes = kirsenDouble(cgs[0], cgs[1])
+			case 3:
+				givenNameRunes = kirsenTriple(cgs[0], cgs[1], cgs[2], MaxNames-total-len(rankNames))
+			}
 
-						if cg1 != nil && len(cg1) > 0 {
-							givenNameRunes = kirsenDouble(cg0, cg1)
-						}
-					} else {
-						// Single
-						givenNameRunes = kirsenSingle(cg0)
+			for _, v := range givenNameRunes {
+				name = NewNameRunes(c.FamilyNameRunes, v[:m], v[m:])
+				name.SetStrokeSchool(c.StrokeSchool)
+				if c.MinPhonetics > 0 {
+					name.Normalize()
+					if analyzePhonetics(name.PinyinTone, name.Simplified.FamilyName.Len, kirsen.language).Score < c.MinPhonetics {
+						continue
 					}
+				}
 
-					for _, v := range givenNameRunes {
-						if p > 0 && c.PrefixNameRunes[0] != v[0] {
-							continue
-						}
-
-						if s > 0 && c.SuffixNameRunes[0] != v[len(v)-1] {
-							continue
-						}
-
-						if topRank == 0 {
-							topRank = rank
-						}
+				name.Rank = rank
+				name.ElementFill = kirsen.GanzhiFiveElements.fill(v)
+				rankNames = append(rankNames, name)
+			}
 
-						name = NewNameRunes(c.FamilyNameRunes, nil, v)
-						name.Rank = rank
-						nameList = append(nameList, name)
-						total++
-					}
-				}
+			if total+len(rankNames) >= MaxNames {
+				break
 			}
 		}
 
+		if len(rankNames) > 0 && topRank == 0 {
+			topRank = rank
+		}
+
+		sort.SliceStable(rankNames, func(i, j int) bool {
+			return rankNames[i].ElementFill.Score > rankNames[j].ElementFill.Score
+		})
+
+		nameList = append(nameList, rankNames...)
+		total += len(rankNames)
 		if c.QueryNums > 0 && total > c.QueryNums {
 			break
 		}
@@ -511,17 +761,33 @@ func Kirsen(language int, c *KirsenConditions, birthTime int64, loc utils.Locati
 		total = c.QueryNums
 	}
 
+	if len(nameList) > MaxNames {
+		nameList = nameList[:MaxNames]
+		total = MaxNames
+	}
+
 	kirsen.List = make(map[string][]*Name)
 	for _, name = range nameList {
 		name.Normalize()
 		name.RemoveUnihan()
-		v := list.QueryCommonNames(fmt.Sprintf("%s%s", name.Simplified.FamilyName.Str, name.Simplified.GivenName.Str))
+		name.localize(kirsen.language)
+		name.ElementFill.localize(kirsen.language)
+		v := list.QueryCommonNames(fmt.Sprintf("%s%s%s", name.Simplified.FamilyName.Str, name.Simplified.MiddleName.Str, name.Simplified.GivenName.Str))
 		if v > 0 {
 			name.IsCommon = true
 		}
 
-		// Re-arrange kirsen list
-		group := name.Traditional.GivenName.Runes[0]
+		// Re-arrange kirsen list by first free character
+		full := append(append([]rune{}, name.Traditional.MiddleName.Runes...), name.Traditional.GivenName.Runes...)
+		if len(full) == 0 {
+			continue
+		}
+
+		group := full[0]
+		if free >= 0 && free < len(full) {
+			group = full[free]
+		}
+
 		kirsen.List[string(group)] = append(kirsen.List[string(group)], name)
 	}
 
@@ -533,7 +799,7 @@ func Kirsen(language int, c *KirsenConditions, birthTime int64, loc utils.Locati
 
 func genTable() {
 	var (
-		maxM = list.MaxStroke * (list.MaxStroke + 1)
+		maxM = strokeKey(list.MaxStroke, list.MaxStroke)
 	)
 
 	strokeTable = make([][][]int, maxM+1)
@@ -0,0 +1,127 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file kirsen_test.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"sync"
+	"testing"
+
+	"yixuan_naming/list"
+)
+
+func resetTripleTables() {
+	tripleTablesLock.Lock()
+	tripleTables = make(map[int]*tripleTable)
+	tripleTablesLock.Unlock()
+}
+
+func TestPackTriple(t *testing.T) {
+	for _, v := range [][3]int{{1, 1, 1}, {list.MaxStroke, list.MaxStroke, list.MaxStroke}, {3, 17, 29}} {
+		g0, g1, g2 := unpackTriple(packTriple(v[0], v[1], v[2]))
+		if g0 != v[0] || g1 != v[1] || g2 != v[2] {
+			t.Errorf("unpackTriple(packTriple(%v)) = %d %d %d", v, g0, g1, g2)
+		}
+	}
+}
+
+func TestGetTripleRanksFromTableConcurrent(t *testing.T) {
+	resetTripleTables()
+	defer resetTripleTables()
+
+	const n = 8
+	ret := make([][][]uint16, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ret[i] = GetTripleRanksFromTable(7, 0)
+		}(i)
+	}
+
+	wg.Wait()
+
+	total := 0
+	for _, v := range ret[0] {
+		total += len(v)
+	}
+
+	if total != list.MaxStroke*list.MaxStroke*list.MaxStroke {
+		t.Fatalf("triple table holds %d combinations", total)
+	}
+
+	for i := 1; i < n; i++ {
+		if len(ret[i]) == 0 || &ret[i][0] != &ret[0][0] {
+			t.Errorf("request %d built its own table", i)
+		}
+	}
+}
+
+func TestGetTripleRanksFromTableLRU(t *testing.T) {
+	resetTripleTables()
+	defer resetTripleTables()
+
+	// Fill cache with family strokes (1, 0), (2, 0) ... in order
+	first := GetTripleRanksFromTable(1, 0)
+	for i := 1; i < MaxTripleTables; i++ {
+		GetTripleRanksFromTable(i%list.MaxStroke+1, i/list.MaxStroke)
+	}
+
+	// Touch the oldest, the second oldest is evicted instead
+	GetTripleRanksFromTable(1, 0)
+	GetTripleRanksFromTable(10, 10)
+
+	tripleTablesLock.Lock()
+	_, keepFirst := tripleTables[strokeKey(1, 0)]
+	_, keepSecond := tripleTables[strokeKey(2, 0)]
+	size := len(tripleTables)
+	tripleTablesLock.Unlock()
+
+	if !keepFirst || keepSecond {
+		t.Errorf("evicted wrong table, first kept %v, second kept %v", keepFirst, keepSecond)
+	}
+
+	if size != MaxTripleTables {
+		t.Errorf("cache holds %d tables, want %d", size, MaxTripleTables)
+	}
+
+	if &GetTripleRanksFromTable(1, 0)[0] != &first[0] {
+		t.Errorf("recently used table rebuilt")
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
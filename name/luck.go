@@ -0,0 +1,192 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file luck.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"time"
+
+	"yixuan_naming/calendar"
+	"yixuan_naming/texts"
+	"yixuan_naming/utils"
+)
+
+// Luck directions
+const (
+	// LuckBackward : Luck pillars go backward from month pillar
+	LuckBackward = iota
+	// LuckForward : Luck pillars go forward from month pillar
+	LuckForward
+)
+
+const (
+	// LuckPillars : Number of luck pillars (DaYun), ten years each
+	LuckPillars = 8
+	// DefaultAnnualPillars : Default number of annual pillars (LiuNian)
+	DefaultAnnualPillars = 10
+	// MaxAnnualPillars : Maximum number of annual pillars
+	MaxAnnualPillars = 120
+)
+
+type luckAge struct {
+	Years  int `json:"years"`
+	Months int `json:"months"`
+	Days   int `json:"days"`
+}
+
+type luckPillar struct {
+	Index        int              `json:"index"`
+	Ganzhi       utils.GanzhiPair `json:"ganzhi"`
+	GanzhiString string           `json:"ganzhi_alias"`
+	StartAge     int              `json:"start_age"`
+	StartYear    int              `json:"start_year"`
+	EndYear      int              `json:"end_year"`
+}
+
+type annualPillar struct {
+	Year         int              `json:"year"`
+	Age          int              `json:"age"`
+	Ganzhi       utils.GanzhiPair `json:"ganzhi"`
+	GanzhiString string           `json:"ganzhi_alias"`
+	Luck         int              `json:"luck"`
+}
+
+type luck struct {
+	Gender          int             `json:"gender"`
+	Direction       int             `json:"direction"`
+	DirectionString string          `json:"direction_alias"`
+	StartAge        luckAge         `json:"start_age"`
+	StartTime       int64           `json:"start_time"`
+	StartYear       int             `json:"start_year"`
+	Pillars         []*luckPillar   `json:"pillars"`
+	Annuals         []*annualPillar `json:"annuals"`
+}
+
+// shiftGanzhi : Ganzhi pair n steps after (or before if negative) given one in sexagenary cycle
+func shiftGanzhi(gz utils.GanzhiPair, n int) utils.GanzhiPair {
+	return *utils.ParseGanzhi(((gz.Value()+n)%60 + 60) % 60)
+}
+
+// yearGanzhi : Ganzhi pair of year (from LiChun)
+func yearGanzhi(year int) utils.GanzhiPair {
+	return *utils.ParseGanzhi(((year-4)%60 + 60) % 60)
+}
+
+// luckStartAge : Start age of luck by distance to jie, 3 days for 1 year (2 hours for 10 days)
+func luckStartAge(d time.Duration) luckAge {
+	if d < 0 {
+		d = -d
+	}
+
+	days := int(d.Minutes()) / 12
+
+	return luckAge{
+		Years:  days / 360,
+		Months: days % 360 / 30,
+		Days:   days % 30,
+	}
+}
+
+// calculateLuck : Luck pillars and annual pillars from fromYear, nil if gender unknown
+func calculateLuck(c *calendar.Calendar, gender, fromYear, years, language int) *luck {
+	if gender != utils.GenderMale && gender != utils.GenderFemale {
+		return nil
+	}
+
+	if years <= 0 {
+		years = DefaultAnnualPillars
+	}
+
+	if years > MaxAnnualPillars {
+		years = MaxAnnualPillars
+	}
+
+	var (
+		birth      = c.Civil()
+		prev, next = c.Ganzhi.Jie()
+		step       = -1
+		ret        = &luck{Gender: gender, Direction: LuckBackward}
+	)
+
+	// Yang year male and yin year female go forward
+	yang := utils.GanYinYang(c.Ganzhi.Year.TianGan) == utils.YinYangYang
+	if yang == (gender == utils.GenderMale) {
+		ret.Direction = LuckForward
+		step = 1
+	}
+
+	if ret.Direction == LuckForward {
+		ret.StartAge = luckStartAge(next.Sub(birth))
+	} else {
+		ret.StartAge = luckStartAge(birth.Sub(prev))
+	}
+
+	ret.DirectionString = texts.GetAlias(texts.AliasLuckDirection, ret.Direction, language)
+	start := birth.AddDate(ret.StartAge.Years, ret.StartAge.Months, ret.StartAge.Days)
+	ret.StartTime = start.Unix()
+	ret.StartYear = start.Year()
+
+	for i := 0; i < LuckPillars; i++ {
+		p := &luckPillar{
+			Index:     i,
+			Ganzhi:    shiftGanzhi(c.Ganzhi.Month, step*(i+1)),
+			StartAge:  ret.StartAge.Years + i*10,
+			StartYear: ret.StartYear + i*10,
+			EndYear:   ret.StartYear + i*10 + 9,
+		}
+		p.GanzhiString = p.Ganzhi.String(language)
+		ret.Pillars = append(ret.Pillars, p)
+	}
+
+	for year := fromYear; year < fromYear+years; year++ {
+		a := &annualPillar{
+			Year:   year,
+			Age:    year - birth.Year(),
+			Ganzhi: yearGanzhi(year),
+			Luck:   -1,
+		}
+		a.GanzhiString = a.Ganzhi.String(language)
+		if year >= ret.StartYear && year < ret.StartYear+LuckPillars*10 {
+			a.Luck = (year - ret.StartYear) / 10
+		}
+
+		ret.Annuals = append(ret.Annuals, a)
+	}
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
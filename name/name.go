@@ -32,10 +32,9 @@ package name
 
 import (
 	"fmt"
-	"regexp"
-	"strings"
 
 	"yixuan_naming/list"
+	"yixuan_naming/texts"
 	"yixuan_naming/unihan"
 	"yixuan_naming/utils"
 )
@@ -58,13 +57,22 @@ type nameDef struct {
 
 // Name : Name defination
 type Name struct {
-	Original    nameDef  `json:"original,omitempty"`
-	Simplified  nameDef  `json:"simplified,omitempty"`
-	Traditional nameDef  `json:"traditional,omitempty"`
-	PinyinTone  []string `json:"pinyin_tone"`
-	Pinyin      []string `json:"pinyin"`
-	Rank        int      `json:"rank,omitempty"`
-	IsCommon    bool     `json:"is_common"`
+	Original    nameDef      `json:"original,omitempty"`
+	Simplified  nameDef      `json:"simplified,omitempty"`
+	Traditional nameDef      `json:"traditional,omitempty"`
+	PinyinTone  []string     `json:"pinyin_tone"`
+	Pinyin      []string     `json:"pinyin"`
+	Rank        int          `json:"rank,omitempty"`
+	IsCommon    bool         `json:"is_common"`
+	Gender      int          `json:"gender"`
+	GenderAlias string       `json:"gender_alias"`
+	Readings    []reading    `json:"readings"`
+	ElementFill *elementFill `json:"element_fill,omitempty"`
+	normalized  bool
+	overrides   []string
+	school      int
+
+	invalidReadings []string
 }
 
 // NewName : Create name from string
@@ -112,23 +120,18 @@ func (ns *nameSpec) assignUnihan() {
 	ns.Len = len(ns.Runes)
 }
 
-// assignSpec : Assign properties of name specification (known unihan)
-func (ns *nameSpec) assignSpec() {
+// assignSpec : Assign properties of name specification (known unihan), strokes by counter
+func (ns *nameSpec) assignSpec(counter list.StrokeCounter) {
 	var (
-		c      *unihan.HanCharacter
-		fe     int
-		stroke int
+		c  *unihan.HanCharacter
+		fe int
 	)
 
 	ns.Runes = nil
 	for _, c = range ns.Characters {
 		if c != nil {
 			ns.Runes = append(ns.Runes, c.Unicode)
-			stroke = list.QueryStrokeSpecial(c.Unicode)
-			if stroke <= 0 {
-				stroke = c.QueryStrokePrefer()
-			}
-			ns.Strokes = append(ns.Strokes, stroke)
+			ns.Strokes = append(ns.Strokes, counter.Count(c))
 			fe = list.QueryFiveElement(c.Unicode)
 			ns.FiveElements = append(ns.FiveElements, fe)
 		}
@@ -186,106 +189,87 @@ func (ns *nameSpec) traditionalized() []*unihan.HanCharacter {
 	return ret
 }
 
+// SetStrokeSchool : Stroke counting school of name, must be called before Normalize
+func (name *Name) SetStrokeSchool(school int) {
+	name.school = school
+}
+
+// StrokeSchool : Stroke counting school of name
+func (name *Name) StrokeSchool() int {
+	return name.school
+}
+
+// gridName : Name form counted for five grids by stroke school
+func (name *Name) gridName() *nameDef {
+	if list.GetStrokeCounter(name.school).Traditional() {
+		return &name.Traditional
+	}
+
+	return &name.Simplified
+}
+
 // Normalize : Normalize name (simplifed & traditional)
 func (name *Name) Normalize() {
+	if name.normalized {
+		return
+	}
+
+	name.normalized = true
+	counter := list.GetStrokeCounter(name.school)
 	name.Original.FamilyName.assignUnihan()
 	name.Original.MiddleName.assignUnihan()
 	name.Original.GivenName.assignUnihan()
-	name.Original.FamilyName.assignSpec()
-	name.Original.MiddleName.assignSpec()
-	name.Original.GivenName.assignSpec()
-	name.Original.FullNameStr = fmt.Sprintf("%s %s", name.Original.FamilyName.Str, name.Original.GivenName.Str)
+	name.Original.FamilyName.assignSpec(counter)
+	name.Original.MiddleName.assignSpec(counter)
+	name.Original.GivenName.assignSpec(counter)
+	name.Original.FullNameStr = fmt.Sprintf("%s %s%s", name.Original.FamilyName.Str, name.Original.MiddleName.Str, name.Original.GivenName.Str)
 
 	// Simplified
 	name.Simplified.FamilyName.Characters = name.Original.FamilyName.simplify()
-	name.Simplified.FamilyName.assignSpec()
+	name.Simplified.FamilyName.assignSpec(counter)
 	name.Simplified.MiddleName.Characters = name.Original.MiddleName.simplify()
-	name.Simplified.MiddleName.assignSpec()
+	name.Simplified.MiddleName.assignSpec(counter)
 	name.Simplified.GivenName.Characters = name.Original.GivenName.simplify()
-	name.Simplified.GivenName.assignSpec()
-	name.Simplified.FullNameStr = fmt.Sprintf("%s %s", name.Simplified.FamilyName.Str, name.Simplified.GivenName.Str)
+	name.Simplified.GivenName.assignSpec(counter)
+	name.Simplified.FullNameStr = fmt.Sprintf("%s %s%s", name.Simplified.FamilyName.Str, name.Simplified.MiddleName.Str, name.Simplified.GivenName.Str)
 
 	// Traditional
 	name.Traditional.FamilyName.Characters = name.Original.FamilyName.traditionalized()
-	name.Traditional.FamilyName.assignSpec()
+	name.Traditional.FamilyName.assignSpec(counter)
 	name.Traditional.MiddleName.Characters = name.Original.MiddleName.traditionalized()
-	name.Traditional.MiddleName.assignSpec()
+	name.Traditional.MiddleName.assignSpec(counter)
 	name.Traditional.GivenName.Characters = name.Original.GivenName.traditionalized()
-	name.Traditional.GivenName.assignSpec()
-	name.Traditional.FullNameStr = fmt.Sprintf("%s %s", name.Traditional.FamilyName.Str, name.Traditional.GivenName.Str)
-
-	_stripTone := func(pinyin string) string {
-		var (
-			re  *regexp.Regexp
-			ret string
-		)
-
-		re = regexp.MustCompile(`ā|á|ǎ|à`)
-		ret = re.ReplaceAllString(pinyin, "a")
-		re = regexp.MustCompile(`ō|ó|ǒ|ò`)
-		ret = re.ReplaceAllString(ret, "o")
-		re = regexp.MustCompile(`ê|ē|é|ě|è`)
-		ret = re.ReplaceAllString(ret, "e")
-		re = regexp.MustCompile(`ī|í|ǐ|ì`)
-		ret = re.ReplaceAllString(ret, "i")
-		re = regexp.MustCompile(`ū|ú|ǔ|ù`)
-		ret = re.ReplaceAllString(ret, "u")
-		re = regexp.MustCompile(`ǖ|ǘ|ǚ|ǜ|ü`)
-		ret = re.ReplaceAllString(ret, "yu")
-
-		return ret
-	}
+	name.Traditional.GivenName.assignSpec(counter)
+	name.Traditional.FullNameStr = fmt.Sprintf("%s %s%s", name.Traditional.FamilyName.Str, name.Traditional.MiddleName.Str, name.Traditional.GivenName.Str)
+
+	// Gender tag of middle & given name
+	name.Gender = runesGender(append(append([]rune{}, name.Original.MiddleName.Runes...), name.Original.GivenName.Runes...))
+
+	// Readings of full name, resolved by position and previous character
+	var prev rune
+	pos := 0
+	for k, ns := range []*nameSpec{&name.Simplified.FamilyName, &name.Simplified.MiddleName, &name.Simplified.GivenName} {
+		for _, v := range ns.Characters {
+			override := ""
+			if pos < len(name.overrides) {
+				override = name.overrides[pos]
+			}
 
-	_getPinyin := func(c *unihan.HanCharacter) (string, string) {
-		var (
-			pinyin     = "_"
-			pinyinTone = "_"
-			parts      []string
-		)
-
-		pinyinTone = list.QueryPinyinSpecial(c.Unicode)
-		if pinyinTone != "" && pinyinTone != "_" {
-			pinyin = _stripTone(pinyinTone)
-		} else {
-			if c != nil && c.Readings != nil {
-				if c.Readings["kMandarin"] != nil {
-					pinyin = _stripTone(c.Readings["kMandarin"].Reading)
-					pinyinTone = c.Readings["kMandarin"].Reading
-				} else {
-					if c.Readings["kXHC1983"] != nil {
-						// XianDaiHanYuCiDian
-						parts = strings.Split(c.Readings["kXHC1983"].Reading, " ")
-						parts = strings.Split(parts[0], ":")
-					} else if c.Readings["kHanyuPinyin"] != nil {
-						parts = strings.Split(c.Readings["kHanyuPinyin"].Reading, ":")
-					}
-
-					if parts != nil && len(parts) == 2 {
-						parts = strings.Split(parts[1], ",")
-						pinyin = _stripTone(parts[0])
-						pinyinTone = parts[0]
-					}
-				}
+			rd, ok := chooseReading(v, prev, k == 0, override)
+			if !ok {
+				name.invalidReadings = append(name.invalidReadings, override)
 			}
-		}
 
-		return pinyin, pinyinTone
-	}
+			name.Readings = append(name.Readings, rd)
+			name.Pinyin = append(name.Pinyin, stripTone(rd.Chosen))
+			name.PinyinTone = append(name.PinyinTone, rd.Chosen)
+			prev = 0
+			if v != nil {
+				prev = v.Unicode
+			}
 
-	for _, v := range name.Simplified.FamilyName.Characters {
-		p, pt := _getPinyin(v)
-		name.Pinyin = append(name.Pinyin, p)
-		name.PinyinTone = append(name.PinyinTone, pt)
-	}
-	for _, v := range name.Simplified.MiddleName.Characters {
-		p, pt := _getPinyin(v)
-		name.Pinyin = append(name.Pinyin, p)
-		name.PinyinTone = append(name.PinyinTone, pt)
-	}
-	for _, v := range name.Simplified.GivenName.Characters {
-		p, pt := _getPinyin(v)
-		name.Pinyin = append(name.Pinyin, p)
-		name.PinyinTone = append(name.PinyinTone, pt)
+			pos++
+		}
 	}
 
 	for i, v := range name.Original.FamilyName.FiveElements {
@@ -341,6 +325,14 @@ func (name *Name) RemoveUnihan() {
 	return
 }
 
+// localize : Aliases of name properties in language
+func (name *Name) localize(language int) {
+	name.GenderAlias = texts.GetAlias(texts.AliasGenderTag, name.Gender, language)
+	for i := range name.Readings {
+		name.Readings[i].SourceAlias = texts.GetAlias(texts.AliasReadingSource, name.Readings[i].Source, language)
+	}
+}
+
 /*
  * Local variables:
  * tab-width: 4
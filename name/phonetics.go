@@ -0,0 +1,294 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file phonetics.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"strings"
+	"yixuan_naming/texts"
+)
+
+// Tone classes
+const (
+	// ToneUnknown : No reading
+	ToneUnknown = iota
+	// ToneLevel : Ping, first & second tones
+	ToneLevel
+	// ToneOblique : Ze, third & fourth tones
+	ToneOblique
+	// ToneLight : Neutral tone
+	ToneLight
+)
+
+// Phonetic issues
+const (
+	// PhoneticAllLevel : All syllables in level tone
+	PhoneticAllLevel = iota
+	// PhoneticAllOblique : All syllables in oblique tone
+	PhoneticAllOblique
+	// PhoneticRepeatedTone : Adjacent syllables in same tone
+	PhoneticRepeatedTone
+	// PhoneticAlliteration : Adjacent syllables with same initial (ShuangSheng)
+	PhoneticAlliteration
+	// PhoneticRhyme : Adjacent syllables with same final (DieYun)
+	PhoneticRhyme
+	// PhoneticClash : Awkward liaison between family name and given name
+	PhoneticClash
+)
+
+type phoneticSyllable struct {
+	Pinyin         string `json:"pinyin"`
+	Tone           int    `json:"tone"`
+	ToneClass      int    `json:"tone_class"`
+	ToneClassAlias string `json:"tone_class_alias"`
+	Initial        string `json:"initial"`
+	Final          string `json:"final"`
+}
+
+type phoneticIssue struct {
+	Issue      int    `json:"issue"`
+	IssueAlias string `json:"issue_alias"`
+	Position   int    `json:"position"`
+}
+
+type phonetics struct {
+	Syllables []phoneticSyllable `json:"syllables"`
+	Pattern   string             `json:"pattern"`
+	Issues    []phoneticIssue    `json:"issues"`
+	Score     int                `json:"score"`
+}
+
+var (
+	toneMarks = map[rune]struct {
+		base rune
+		tone int
+	}{
+		'ā': {'a', 1}, 'á': {'a', 2}, 'ǎ': {'a', 3}, 'à': {'a', 4},
+		'ō': {'o', 1}, 'ó': {'o', 2}, 'ǒ': {'o', 3}, 'ò': {'o', 4},
+		'ē': {'e', 1}, 'é': {'e', 2}, 'ě': {'e', 3}, 'è': {'e', 4},
+		'ī': {'i', 1}, 'í': {'i', 2}, 'ǐ': {'i', 3}, 'ì': {'i', 4},
+		'ū': {'u', 1}, 'ú': {'u', 2}, 'ǔ': {'u', 3}, 'ù': {'u', 4},
+		'ǖ': {'v', 1}, 'ǘ': {'v', 2}, 'ǚ': {'v', 3}, 'ǜ': {'v', 4},
+		'ü': {'v', 5}, 'ê': {'e', 5},
+	}
+	pinyinInitials = []string{
+		"zh", "ch", "sh",
+		"b", "p", "m", "f", "d", "t", "n", "l", "g", "k", "h",
+		"j", "q", "x", "r", "z", "c", "s",
+	}
+
+	// Contracted finals after initials
+	contractedFinals = map[string]string{
+		"iu": "iou",
+		"ui": "uei",
+		"un": "uen",
+	}
+
+	// Deductions of phonetic issues
+	phoneticDeductions = []int{20, 20, 10, 10, 10, 15}
+)
+
+// parseSyllable : Split toned pinyin into plain syllable and tone (1 - 5, 0 for unknown)
+func parseSyllable(pinyinTone string) (string, int) {
+	var (
+		b    strings.Builder
+		tone = 5
+	)
+
+	if pinyinTone == "" || pinyinTone == "_" {
+		return "", 0
+	}
+
+	for _, r := range strings.ToLower(pinyinTone) {
+		if m, ok := toneMarks[r]; ok {
+			b.WriteRune(m.base)
+			if m.tone < 5 {
+				tone = m.tone
+			}
+		} else if r >= '1' && r <= '5' {
+			tone = int(r - '0')
+		} else if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String(), tone
+}
+
+// splitSyllable : Initial & full final of plain syllable, ü written as v.
+// Y & w are spellings of zero initial, restored to finals (yu -> v, yi -> i, ya -> ia, wu -> u, wei -> uei)
+func splitSyllable(syllable string) (string, string) {
+	switch {
+	case strings.HasPrefix(syllable, "yu"):
+		return "", "v" + syllable[2:]
+	case strings.HasPrefix(syllable, "yi"):
+		return "", syllable[1:]
+	case strings.HasPrefix(syllable, "y"):
+		return "", "i" + syllable[1:]
+	case strings.HasPrefix(syllable, "wu"):
+		return "", syllable[1:]
+	case strings.HasPrefix(syllable, "w"):
+		return "", "u" + syllable[1:]
+	}
+
+	for _, i := range pinyinInitials {
+		if strings.HasPrefix(syllable, i) && len(syllable) > len(i) {
+			final := syllable[len(i):]
+
+			// U after j, q & x is ü
+			if (i == "j" || i == "q" || i == "x") && strings.HasPrefix(final, "u") {
+				final = "v" + final[1:]
+			}
+
+			if v, ok := contractedFinals[final]; ok {
+				final = v
+			}
+
+			return i, final
+		}
+	}
+
+	return "", syllable
+}
+
+func toneClass(tone int) int {
+	switch tone {
+	case 1, 2:
+		return ToneLevel
+	case 3, 4:
+		return ToneOblique
+	case 5:
+		return ToneLight
+	}
+
+	return ToneUnknown
+}
+
+// clashed : Nasal ending of family name runs into beginning of given name
+func clashed(prev, next phoneticSyllable) bool {
+	switch {
+	case strings.HasSuffix(prev.Final, "ng"):
+		return next.Initial == "" || next.Initial == "g" || next.Initial == "k" || next.Initial == "h"
+	case strings.HasSuffix(prev.Final, "n"):
+		return next.Initial == "" || next.Initial == "n" || next.Initial == "l"
+	}
+
+	return false
+}
+
+// analyzePhonetics : Tone pattern & phonetic harmony of full name readings
+func analyzePhonetics(pinyinTone []string, familyLen int, language int) phonetics {
+	var (
+		ret            phonetics
+		patterns       []string
+		known          int
+		level, oblique int
+		prev, syllable phoneticSyllable
+		plain          string
+		i              int
+		issue          func(int, int)
+	)
+
+	issue = func(t, position int) {
+		ret.Issues = append(ret.Issues, phoneticIssue{
+			Issue:      t,
+			IssueAlias: texts.GetAlias(texts.AliasPhoneticIssue, t, language),
+			Position:   position,
+		})
+		ret.Score -= phoneticDeductions[t]
+	}
+
+	ret.Score = MaxRank
+	for i = range pinyinTone {
+		syllable = phoneticSyllable{}
+		plain, syllable.Tone = parseSyllable(pinyinTone[i])
+		syllable.Pinyin = pinyinTone[i]
+		syllable.ToneClass = toneClass(syllable.Tone)
+		syllable.ToneClassAlias = texts.GetAlias(texts.AliasToneClass, syllable.ToneClass, language)
+		syllable.Initial, syllable.Final = splitSyllable(plain)
+		ret.Syllables = append(ret.Syllables, syllable)
+		patterns = append(patterns, syllable.ToneClassAlias)
+
+		switch syllable.ToneClass {
+		case ToneLevel:
+			level++
+		case ToneOblique:
+			oblique++
+		}
+
+		if syllable.ToneClass != ToneUnknown {
+			known++
+		}
+
+		if i > 0 && prev.Final != "" && syllable.Final != "" {
+			if prev.Tone > 0 && prev.Tone == syllable.Tone {
+				issue(PhoneticRepeatedTone, i)
+			}
+
+			if prev.Initial != "" && prev.Initial == syllable.Initial {
+				issue(PhoneticAlliteration, i)
+			}
+
+			if prev.Final == syllable.Final {
+				issue(PhoneticRhyme, i)
+			}
+
+			if i == familyLen && clashed(prev, syllable) {
+				issue(PhoneticClash, i)
+			}
+		}
+
+		prev = syllable
+	}
+
+	if known > 1 {
+		if level == known {
+			issue(PhoneticAllLevel, -1)
+		} else if oblique == known {
+			issue(PhoneticAllOblique, -1)
+		}
+	}
+
+	if ret.Score < 0 {
+		ret.Score = 0
+	}
+
+	ret.Pattern = strings.Join(patterns, "")
+
+	return ret
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
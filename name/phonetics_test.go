@@ -0,0 +1,154 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file phonetics_test.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestSplitSyllable : Initials & full finals, y & w as zero initial
+func TestSplitSyllable(t *testing.T) {
+	cases := map[string][2]string{
+		"zhang": {"zh", "ang"},
+		"li":    {"l", "i"},
+		"er":    {"", "er"},
+		"ai":    {"", "ai"},
+		"yi":    {"", "i"},
+		"yin":   {"", "in"},
+		"ya":    {"", "ia"},
+		"you":   {"", "iou"},
+		"yong":  {"", "iong"},
+		"yu":    {"", "v"},
+		"yue":   {"", "ve"},
+		"yuan":  {"", "van"},
+		"wu":    {"", "u"},
+		"wei":   {"", "uei"},
+		"wang":  {"", "uang"},
+		"ju":    {"j", "v"},
+		"xun":   {"x", "vn"},
+		"lv":    {"l", "v"},
+		"liu":   {"l", "iou"},
+		"gui":   {"g", "uei"},
+		"lun":   {"l", "uen"},
+	}
+
+	for syllable, want := range cases {
+		initial, final := splitSyllable(syllable)
+		if initial != want[0] || final != want[1] {
+			t.Errorf("%s splits into <%s> <%s>, <%s> <%s> expected", syllable, initial, final, want[0], want[1])
+		}
+	}
+}
+
+// TestParseSyllable : Tone marks, tone numbers and neutral tone
+func TestParseSyllable(t *testing.T) {
+	cases := []struct {
+		pinyin string
+		plain  string
+		tone   int
+	}{
+		{"zhāng", "zhang", 1},
+		{"míng", "ming", 2},
+		{"lǚ", "lv", 3},
+		{"xiè", "xie", 4},
+		{"de", "de", 5},
+		{"ma3", "ma", 3},
+		{"_", "", 0},
+		{"", "", 0},
+	}
+
+	for _, c := range cases {
+		plain, tone := parseSyllable(c.pinyin)
+		if plain != c.plain || tone != c.tone {
+			t.Errorf("%s parses into %s %d, %s %d expected", c.pinyin, plain, tone, c.plain, c.tone)
+		}
+	}
+}
+
+// TestAnalyzePhonetics : Tone pattern, issues and score of full names
+func TestAnalyzePhonetics(t *testing.T) {
+	cases := []struct {
+		pinyin    []string
+		familyLen int
+		classes   []int
+		issues    string // issue@position
+		score     int
+	}{
+		// No issues
+		{[]string{"lǐ", "míng"}, 1, []int{ToneOblique, ToneLevel}, "[]", 100},
+		// Zero initials of y & w do not alliterate
+		{[]string{"lǐ", "yú", "wēi"}, 1, []int{ToneOblique, ToneLevel, ToneLevel}, "[]", 100},
+		{[]string{"wú", "yì", "wěi"}, 1, []int{ToneLevel, ToneOblique, ToneOblique}, "[]", 100},
+		{[]string{"lǐ", "yí", "yǔ"}, 1, []int{ToneOblique, ToneLevel, ToneOblique}, "[4@1]", 100 - 10},
+		// Same final after zero initial still rhymes
+		{[]string{"wú", "wǔ"}, 1, []int{ToneLevel, ToneOblique}, "[4@1]", 100 - 10},
+		{[]string{"yǔ", "jù"}, 1, []int{ToneOblique, ToneOblique}, "[4@1 1@-1]", 100 - 10 - 20},
+		// Nasal ending runs into zero initial of given name
+		{[]string{"zhāng", "wěi"}, 1, []int{ToneLevel, ToneOblique}, "[5@1]", 100 - 15},
+		{[]string{"yáng", "yǔ"}, 1, []int{ToneLevel, ToneOblique}, "[5@1]", 100 - 15},
+		{[]string{"zhāng", "xīn"}, 1, []int{ToneLevel, ToneLevel}, "[2@1 0@-1]", 100 - 10 - 20},
+		// Repeated tone, alliteration and rhyme, all level
+		{[]string{"wáng", "fāng", "fāng"}, 1, []int{ToneLevel, ToneLevel, ToneLevel}, "[2@2 3@2 4@2 0@-1]", 100 - 10 - 10 - 10 - 20},
+		// Unknown readings skipped
+		{[]string{"_", "lín"}, 1, []int{ToneUnknown, ToneLevel}, "[]", 100},
+	}
+
+	for _, c := range cases {
+		ret := analyzePhonetics(c.pinyin, c.familyLen, 0)
+		var issues []string
+		for _, i := range ret.Issues {
+			issues = append(issues, fmt.Sprintf("%d@%d", i.Issue, i.Position))
+		}
+
+		if fmt.Sprint(issues) != c.issues && !(len(issues) == 0 && c.issues == "[]") {
+			t.Errorf("%v has issues %v, %s expected", c.pinyin, issues, c.issues)
+		}
+
+		if ret.Score != c.score {
+			t.Errorf("%v scores %d, %d expected", c.pinyin, ret.Score, c.score)
+		}
+
+		for i, s := range ret.Syllables {
+			if s.ToneClass != c.classes[i] {
+				t.Errorf("%v has tone class %d at %d, %d expected", c.pinyin, s.ToneClass, i, c.classes[i])
+			}
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
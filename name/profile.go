@@ -0,0 +1,191 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file profile.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"fmt"
+	"math"
+)
+
+// ScoringProfile : Weights of name ranking
+type ScoringProfile struct {
+	Name string `mapstructure:"name" json:"name"`
+
+	// Five rules & three elements
+	TianGe       float64 `mapstructure:"tian_ge" json:"tian_ge"`
+	RenGe        float64 `mapstructure:"ren_ge" json:"ren_ge"`
+	DiGe         float64 `mapstructure:"di_ge" json:"di_ge"`
+	WaiGe        float64 `mapstructure:"wai_ge" json:"wai_ge"`
+	ZongGe       float64 `mapstructure:"zong_ge" json:"zong_ge"`
+	ThreeElement float64 `mapstructure:"three_element" json:"three_element"`
+
+	// Sections of total rank
+	FiveRules       float64 `mapstructure:"five_rules" json:"five_rules"`
+	EightCharacters float64 `mapstructure:"eight_characters" json:"eight_characters"`
+	FiveElements    float64 `mapstructure:"five_elements" json:"five_elements"`
+	Animal          float64 `mapstructure:"animal" json:"animal"`
+	Phonetics       float64 `mapstructure:"phonetics" json:"phonetics"`
+
+	// Levels : Minimum total rank of DaJi, Ji, BanJi and Xiong
+	Levels []int `mapstructure:"levels" json:"levels"`
+}
+
+type rankSection struct {
+	score  int
+	weight float64
+}
+
+var (
+	rankScores     = []int{0, 0, 25, 50, 75, 100}
+	scoringProfile = DefaultScoringProfile()
+)
+
+// DefaultScoringProfile : Built-in scoring profile
+func DefaultScoringProfile() *ScoringProfile {
+	return &ScoringProfile{
+		Name:            "default",
+		TianGe:          0.13,
+		RenGe:           0.21,
+		DiGe:            0.13,
+		WaiGe:           0.13,
+		ZongGe:          0.2,
+		ThreeElement:    0.2,
+		FiveRules:       0.5,
+		EightCharacters: 0.3,
+		FiveElements:    0.2,
+		Animal:          0.1,
+		Phonetics:       0.1,
+		Levels:          []int{90, 80, 60, 40},
+	}
+}
+
+// SetScoringProfile : Validate and apply scoring profile, must be called before FillRankTable
+func SetScoringProfile(p *ScoringProfile) error {
+	if p == nil {
+		return fmt.Errorf("Empty scoring profile")
+	}
+
+	for _, w := range []float64{
+		p.TianGe, p.RenGe, p.DiGe, p.WaiGe, p.ZongGe, p.ThreeElement,
+		p.FiveRules, p.EightCharacters, p.FiveElements, p.Animal, p.Phonetics,
+	} {
+		if w < 0 {
+			return fmt.Errorf("Negative weight in scoring profile <%s>", p.Name)
+		}
+	}
+
+	if p.TianGe+p.RenGe+p.DiGe+p.WaiGe+p.ZongGe+p.ThreeElement <= 0 {
+		return fmt.Errorf("No five rules weight in scoring profile <%s>", p.Name)
+	}
+
+	if len(p.Levels) != 4 {
+		return fmt.Errorf("Scoring profile <%s> must define 4 levels", p.Name)
+	}
+
+	for i := 1; i < len(p.Levels); i++ {
+		if p.Levels[i] > p.Levels[i-1] {
+			return fmt.Errorf("Levels of scoring profile <%s> must be descending", p.Name)
+		}
+	}
+
+	scoringProfile = p
+
+	return nil
+}
+
+// GetScoringProfile : Current scoring profile
+func GetScoringProfile() *ScoringProfile {
+	return scoringProfile
+}
+
+// fiveRulesRank : Weighted rank of five rules & three elements by their rule ranks
+func (p *ScoringProfile) fiveRulesRank(tian, ren, di, zong, wai, three int) int {
+	ret := int(
+		math.Ceil(float64(rankScores[ren])*p.RenGe) +
+			math.Ceil(float64(rankScores[zong])*p.ZongGe) +
+			math.Ceil(float64(rankScores[tian])*p.TianGe) +
+			math.Ceil(float64(rankScores[di])*p.DiGe) +
+			math.Ceil(float64(rankScores[wai])*p.WaiGe) +
+			math.Ceil(float64(rankScores[three])*p.ThreeElement))
+	if ret > MaxRank {
+		ret = MaxRank
+	}
+
+	return ret
+}
+
+// totalRank : Weighted average of rank sections
+func (p *ScoringProfile) totalRank(sections []rankSection) int {
+	var (
+		total  float64
+		weight float64
+	)
+
+	for _, s := range sections {
+		total += float64(s.score) * s.weight
+		weight += s.weight
+	}
+
+	if weight <= 0 {
+		return 0
+	}
+
+	ret := int(math.Round(total / weight))
+	if ret > MaxRank {
+		ret = MaxRank
+	}
+
+	return ret
+}
+
+// level : Rank level (RankDaXiong - RankDaJi) of total rank
+func (p *ScoringProfile) level(total int) int {
+	switch {
+	case total >= p.Levels[0]:
+		return RankDaJi
+	case total >= p.Levels[1]:
+		return RankJi
+	case total >= p.Levels[2]:
+		return RankBanJi
+	case total >= p.Levels[3]:
+		return RankXiong
+	}
+
+	return RankDaXiong
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,141 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file profile_test.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import "testing"
+
+func testProfile() *ScoringProfile {
+	return &ScoringProfile{
+		Name:      "test",
+		RenGe:     1,
+		FiveRules: 1,
+		Animal:    1,
+		Phonetics: 2,
+		Levels:    []int{95, 70, 50, 30},
+	}
+}
+
+// TestSetScoringProfileInvalid : Profiles rejected by validation leave current profile untouched
+func TestSetScoringProfileInvalid(t *testing.T) {
+	defer SetScoringProfile(DefaultScoringProfile())
+
+	cases := []struct {
+		name string
+		mod  func(p *ScoringProfile)
+	}{
+		{"negative five rules weight", func(p *ScoringProfile) { p.ZongGe = -0.1 }},
+		{"negative section weight", func(p *ScoringProfile) { p.Phonetics = -1 }},
+		{"no five rules weight", func(p *ScoringProfile) { p.RenGe = 0 }},
+		{"three levels", func(p *ScoringProfile) { p.Levels = []int{90, 70, 50} }},
+		{"five levels", func(p *ScoringProfile) { p.Levels = []int{90, 80, 70, 50, 30} }},
+		{"ascending levels", func(p *ScoringProfile) { p.Levels = []int{30, 50, 70, 95} }},
+	}
+
+	if err := SetScoringProfile(nil); err == nil {
+		t.Error("Nil profile accepted")
+	}
+
+	for _, c := range cases {
+		p := testProfile()
+		c.mod(p)
+		if err := SetScoringProfile(p); err == nil {
+			t.Errorf("Profile with %s accepted", c.name)
+		}
+
+		if GetScoringProfile().Name != "default" {
+			t.Fatalf("Profile with %s applied", c.name)
+		}
+	}
+
+	if err := SetScoringProfile(testProfile()); err != nil {
+		t.Errorf("Valid profile rejected : %s", err)
+	}
+}
+
+// TestScoringProfileRank : Five rules, total rank and level follow weights of profile
+func TestScoringProfileRank(t *testing.T) {
+	defer SetScoringProfile(DefaultScoringProfile())
+
+	data := &RankData{}
+	data.Rank.RankFiveRules = 90
+	data.Rank.RankEightCharacters = 10
+	data.Rank.RankFiveElements = 20
+	data.Rank.RankPhonetics = 60
+	data.Rank.RankAnimal = 30
+
+	cases := []struct {
+		profile  *ScoringProfile
+		fiveDaJi int
+		fiveBan  int
+		total    int
+		level    int
+	}{
+		// 100*0.21 + 0*0.2 + 0*0.13*3 + 0*0.2, rounded up per rule
+		{DefaultScoringProfile(), 21, 11, 51, RankXiong},
+		// Only RenGe counts; (90 + 30 + 60*2) / 4, weightless eight characters and five elements ignored
+		{testProfile(), 100, 50, 60, RankBanJi},
+	}
+
+	for _, c := range cases {
+		if err := SetScoringProfile(c.profile); err != nil {
+			t.Fatal(err)
+		}
+
+		if v := c.profile.fiveRulesRank(RankDaXiong, RankDaJi, RankDaXiong, RankDaXiong, RankDaXiong, RankDaXiong); v != c.fiveDaJi {
+			t.Errorf("Profile <%s> gives five rules rank %d for DaJi RenGe, %d expected", c.profile.Name, v, c.fiveDaJi)
+		}
+
+		if v := c.profile.fiveRulesRank(RankDaXiong, RankBanJi, RankDaXiong, RankDaXiong, RankDaXiong, RankDaXiong); v != c.fiveBan {
+			t.Errorf("Profile <%s> gives five rules rank %d for BanJi RenGe, %d expected", c.profile.Name, v, c.fiveBan)
+		}
+
+		data.calculateRankTotal()
+		if data.Rank.Profile != c.profile.Name || data.Rank.RankTotal != c.total || data.Rank.RankLevel != c.level {
+			t.Errorf("Profile <%s> gives total %d level %d, %d and %d expected", data.Rank.Profile, data.Rank.RankTotal, data.Rank.RankLevel, c.total, c.level)
+		}
+	}
+
+	// Level boundaries are inclusive
+	p := testProfile()
+	for total, level := range map[int]int{100: RankDaJi, 95: RankDaJi, 94: RankJi, 70: RankJi, 50: RankBanJi, 30: RankXiong, 29: RankDaXiong} {
+		if v := p.level(total); v != level {
+			t.Errorf("Total %d gives level %d, %d expected", total, v, level)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -32,7 +32,6 @@ package name
 
 import (
 	"fmt"
-	"math"
 	"strings"
 
 	"yixuan_naming/calendar"
@@ -107,12 +106,33 @@ type dictFolkways struct {
 	GivenNameFolkway  []*dict.FolkwayItem `json:"given_name_folkway,omitempty"`
 }
 
+type elementFit struct {
+	Character        string `json:"character"`
+	FiveElement      int    `json:"five_element"`
+	FiveElementAlias string `json:"five_element_alias"`
+	Relation         int    `json:"relation"`
+	RelationAlias    string `json:"relation_alias"`
+	RankLike         int    `json:"rank_like"`
+	RankBalance      int    `json:"rank_balance"`
+	Description      string `json:"description"`
+}
+
+type elementsFit struct {
+	Like       int           `json:"like"`
+	LikeAlias  string        `json:"like_alias"`
+	Characters []*elementFit `json:"characters"`
+}
+
 type rank struct {
 	RankFiveRules       int    `json:"rank_five_rules"`
 	RankFiveElements    int    `json:"rank_five_elements"`
 	RankEightCharacters int    `json:"rank_eight_characters"`
+	RankPhonetics       int    `json:"rank_phonetics"`
+	RankAnimal          int    `json:"rank_animal"`
 	RankTotal           int    `json:"rank_total"`
+	RankLevel           int    `json:"rank_level"`
 	RankDescription     string `json:"rank_description"`
+	Profile             string `json:"profile"`
 }
 
 // RankData : struct of name ranking result
@@ -128,7 +148,13 @@ type RankData struct {
 	Calendar           *calendar.Calendar     `json:"calendar"`
 	GanzhiFiveElements GanzhiFiveElementsSpec `json:"ganzhi_five_elements"`
 	SoundFiveElements  SoundFiveElements      `json:"sound_five_elements"`
+	ElementsFit        elementsFit            `json:"elements_fit"`
+	Phonetics          phonetics              `json:"phonetics"`
 	Animal             animal                 `json:"animal"`
+	StrokeSchool       int                    `json:"stroke_school"`
+	StrokeSchoolAlias  string                 `json:"stroke_school_alias"`
+	Hexagrams          hexagrams              `json:"hexagrams"`
+	Luck               *luck                  `json:"luck,omitempty"`
 	Rank               rank                   `json:"rank"`
 	Homonyms           []string               `json:"homonyms"`
 	FamilyNameScore    int                    `json:"family_name_score"`
@@ -137,55 +163,16 @@ type RankData struct {
 }
 
 func (rank *RankData) calculateFiveRules() {
-	// Traditional name prefered
-	n := &rank.Name.Traditional
-	if n.FamilyName.Len >= 2 {
-		// hyphenated
-		rank.FiveRules.TianGe = n.FamilyName.Strokes[0] + n.FamilyName.Strokes[1]
-		if n.GivenName.Len >= 1 {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[1] + n.GivenName.Strokes[0]
-		} else {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[1]
-		}
-	} else if n.FamilyName.Len == 1 {
-		rank.FiveRules.TianGe = n.FamilyName.Strokes[0] + 1
-		if n.GivenName.Len >= 1 {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[0] + n.GivenName.Strokes[0]
-		} else {
-			rank.FiveRules.RenGe = n.FamilyName.Strokes[0]
-		}
-	}
-
-	if n.GivenName.Len >= 2 {
-		rank.FiveRules.DiGe = n.GivenName.Strokes[0] + n.GivenName.Strokes[1]
-	} else if n.GivenName.Len == 1 {
-		rank.FiveRules.DiGe = n.GivenName.Strokes[0] + 1
+	// Form of name counted by stroke school
+	n := rank.Name.gridName()
+	family := n.FamilyName.Strokes
+	if len(family) > 2 {
+		family = family[:2]
 	}
 
-	if n.FamilyName.Len >= 2 {
-		if n.GivenName.Len >= 2 {
-			rank.FiveRules.WaiGe = n.FamilyName.Strokes[0] + n.GivenName.Strokes[1]
-		} else if n.GivenName.Len == 1 {
-			rank.FiveRules.WaiGe = n.FamilyName.Strokes[0] + 1
-		}
-	} else if n.FamilyName.Len == 1 {
-		if n.GivenName.Len >= 2 {
-			rank.FiveRules.WaiGe = 1 + n.GivenName.Strokes[1]
-		} else if n.GivenName.Len == 1 {
-			rank.FiveRules.WaiGe = 2
-		}
-	}
-
-	for k, h := range n.FamilyName.Strokes {
-		if k < 2 {
-			rank.FiveRules.ZongGe = rank.FiveRules.ZongGe + h
-		}
-	}
-	for k, h := range n.GivenName.Strokes {
-		if k < 2 {
-			rank.FiveRules.ZongGe = rank.FiveRules.ZongGe + h
-		}
-	}
+	// Middle (generation) name counted as given name
+	given := append(append([]int{}, n.MiddleName.Strokes...), n.GivenName.Strokes...)
+	rank.FiveRules.TianGe, rank.FiveRules.RenGe, rank.FiveRules.DiGe, rank.FiveRules.ZongGe, rank.FiveRules.WaiGe = calcFiveGrids(family, given)
 
 	_mod := func(i, m int) int {
 		r := i % m
@@ -247,10 +234,14 @@ func (rank *RankData) calculateEightCharacters() {
 	rank.EightCharacters.Day = &rank.Calendar.Ganzhi.Day
 	rank.EightCharacters.Hour = &rank.Calendar.Ganzhi.Hour
 	rank.EightCharacters.complete()
+	rank.EightCharacters.calculateTenGods(rank.language)
+	rank.EightCharacters.localize(rank.language)
 }
 
 func (rank *RankData) calculateGanzhi() {
 	rank.GanzhiFiveElements = GanzhiFiveElements(rank.Calendar)
+	rank.GanzhiFiveElements.adjust(&rank.EightCharacters)
+	rank.GanzhiFiveElements.weigh(&rank.EightCharacters, rank.language)
 }
 
 func (rank *RankData) calculateSounds() {
@@ -263,18 +254,49 @@ func (rank *RankData) calculateSounds() {
 func (rank *RankData) calculateAnimal() {
 	rank.Animal.Radicals = getAnimalRadicals(rank.Calendar.Lunar.AnimalSign, rank.language)
 	rank.Animal.Years = texts.GetMessage(texts.MessageAnimalYear, rank.Calendar.Lunar.AnimalSign, rank.language)
-}
+	rank.Animal.Characters = nil
+	rank.Animal.Warnings = nil
+	rank.Rank.RankAnimal = AnimalScoreNeutral
+	if rank.Animal.Radicals == nil {
+		return
+	}
 
-func (rank *RankData) calculateRankFiveRules() {
-	scores := []int{0, 0, 25, 50, 75, 100}
+	// Given name characters, simplified and traditional forms
+	var (
+		simplified  = append(append([]rune{}, rank.Name.Simplified.MiddleName.Runes...), rank.Name.Simplified.GivenName.Runes...)
+		traditional = append(append([]rune{}, rank.Name.Traditional.MiddleName.Runes...), rank.Name.Traditional.GivenName.Runes...)
+		total       int
+	)
+
+	for i, r := range simplified {
+		forms := []rune{r}
+		if i < len(traditional) && traditional[i] != r {
+			forms = append(forms, traditional[i])
+		}
+
+		fit, ominousRs := fitAnimal(rank.Calendar.Lunar.AnimalSign, forms...)
+		if fit == nil {
+			continue
+		}
+
+		for j, o := range fit.Ominous {
+			rank.Animal.Warnings = append(rank.Animal.Warnings, animalWarning{
+				Character: fit.Character,
+				Radical:   utils.GetRadical(ominousRs[j]),
+				Meaning:   rank.Animal.Radicals.Ominous[o].Meaning,
+			})
+		}
+
+		rank.Animal.Characters = append(rank.Animal.Characters, fit)
+		total += fit.Score
+	}
 
-	// FiveRules
-	scoreTian := scores[rank.FiveRules.TianGeRule.Rank]
-	scoreRen := scores[rank.FiveRules.RenGeRule.Rank]
-	scoreDi := scores[rank.FiveRules.DiGeRule.Rank]
-	scoreZong := scores[rank.FiveRules.ZongGeRule.Rank]
-	scoreWai := scores[rank.FiveRules.WaiGeRule.Rank]
+	if len(rank.Animal.Characters) > 0 {
+		rank.Rank.RankAnimal = total / len(rank.Animal.Characters)
+	}
+}
 
+func (rank *RankData) calculateRankFiveRules() {
 	// ThreeRules
 	tianCai := ((rank.FiveRules.TianGe - 1) % 10) / 2
 	renCai := ((rank.FiveRules.RenGe - 1) % 10) / 2
@@ -282,26 +304,132 @@ func (rank *RankData) calculateRankFiveRules() {
 	threeElement := tianCai*25 + renCai*5 + diCai
 	rank.FiveRules.ThreeElement = getRuleThreeElement(threeElement, rank.language)
 	rank.FiveRules.ThreeElementRank = texts.GetAlias(texts.AliasRank, rank.FiveRules.ThreeElement.Rank, rank.language)
-	scoreThreeElement := scores[rank.FiveRules.ThreeElement.Rank]
-
-	rank.Rank.RankFiveRules = int(
-		math.Ceil(float64(scoreRen)*0.21) +
-			math.Ceil(float64(scoreZong)*0.2) +
-			math.Ceil(float64(scoreTian)*0.13) +
-			math.Ceil(float64(scoreDi)*0.13) +
-			math.Ceil(float64(scoreWai)*0.13) +
-			math.Ceil(float64(scoreThreeElement)*0.20))
-	if rank.Rank.RankFiveRules > 100 {
-		rank.Rank.RankFiveRules = 100
+
+	rank.Rank.RankFiveRules = scoringProfile.fiveRulesRank(
+		rank.FiveRules.TianGeRule.Rank,
+		rank.FiveRules.RenGeRule.Rank,
+		rank.FiveRules.DiGeRule.Rank,
+		rank.FiveRules.ZongGeRule.Rank,
+		rank.FiveRules.WaiGeRule.Rank,
+		rank.FiveRules.ThreeElement.Rank)
+}
+
+// Score of character five-element against the favourable element (YongShen)
+func elementLikeScore(element, like int) (int, int) {
+	if element < utils.ElementWood || element > utils.ElementWater {
+		return utils.FiveElementCompareInvalid, 50
+	}
+
+	relation := utils.CompareFiveElements(element, like)
+	switch relation {
+	case utils.FiveElementEqual:
+		// YongShen
+		return relation, 100
+	case utils.FiveElementBirth:
+		// XiShen : births YongShen
+		return relation, 80
+	case utils.FiveElementBirthed:
+		// XianShen : drains YongShen
+		return relation, 50
+	case utils.FiveElementKilled:
+		// ChouShen : births JiShen
+		return relation, 30
+	case utils.FiveElementKill:
+		// JiShen : kills YongShen
+		return relation, 10
+	}
+
+	return relation, 50
+}
+
+// Score of character five-element against the balance of ganzhi five-elements
+func elementBalanceScore(element int, count *utils.FiveElementsCount) int {
+	if element < utils.ElementWood || element > utils.ElementWater {
+		return 50
+	}
+
+	v := count.Get(element)
+	if v == 0 {
+		// Missing element
+		return 100
+	}
+
+	min, max := count.MinMax()
+	if max == min {
+		return 60
 	}
+
+	return 20 + 80*(max-v)/(max-min)
 }
 
 func (rank *RankData) calculateRankEightElements() {
+	var (
+		n            = &rank.Name.Simplified
+		like         = rank.EightCharacters.LikeYi
+		runes        []rune
+		elements     []int
+		totalLike    int
+		totalBalance int
+	)
+
+	runes = append(runes, n.MiddleName.Runes...)
+	runes = append(runes, n.GivenName.Runes...)
+	elements = append(elements, n.MiddleName.FiveElements...)
+	elements = append(elements, n.GivenName.FiveElements...)
+
+	rank.ElementsFit.Like = like
+	rank.ElementsFit.LikeAlias = texts.GetAlias(texts.AliasFiveElement, like, rank.language)
+	rank.ElementsFit.Characters = nil
+	for i, r := range runes {
+		if i >= len(elements) {
+			break
+		}
+
+		fit := &elementFit{
+			Character:        string(r),
+			FiveElement:      elements[i],
+			FiveElementAlias: texts.GetAlias(texts.AliasFiveElement, elements[i], rank.language),
+		}
+		fit.Relation, fit.RankLike = elementLikeScore(elements[i], like)
+		fit.RankBalance = elementBalanceScore(elements[i], &rank.GanzhiFiveElements.FiveElementsAdjusted)
+		fit.RelationAlias = texts.GetAlias(texts.AliasFiveElementRelation, fit.Relation, rank.language)
+		fit.Description = texts.GetMessage(texts.MessageElementFitDescription, fit.Relation, rank.language)
+
+		rank.ElementsFit.Characters = append(rank.ElementsFit.Characters, fit)
+		totalLike += fit.RankLike
+		totalBalance += fit.RankBalance
+	}
+
+	if len(rank.ElementsFit.Characters) > 0 {
+		rank.Rank.RankEightCharacters = totalLike / len(rank.ElementsFit.Characters)
+		rank.Rank.RankFiveElements = totalBalance / len(rank.ElementsFit.Characters)
+	}
+}
+
+func (rank *RankData) calculateRankTotal() {
+	p := scoringProfile
+	rank.Rank.Profile = p.Name
+	rank.Rank.RankTotal = p.totalRank([]rankSection{
+		{rank.Rank.RankFiveRules, p.FiveRules},
+		{rank.Rank.RankEightCharacters, p.EightCharacters},
+		{rank.Rank.RankFiveElements, p.FiveElements},
+		{rank.Rank.RankPhonetics, p.Phonetics},
+		{rank.Rank.RankAnimal, p.Animal},
+	})
+	rank.Rank.RankLevel = p.level(rank.Rank.RankTotal)
+	rank.Rank.RankDescription = texts.GetAlias(texts.AliasRank, rank.Rank.RankLevel, rank.language)
+}
+
+func (rank *RankData) calculatePhonetics() {
+	rank.Phonetics = analyzePhonetics(rank.Name.PinyinTone, rank.Name.Simplified.FamilyName.Len, rank.language)
+	rank.Rank.RankPhonetics = rank.Phonetics.Score
 }
 
 func (rank *RankData) calculateRanks() {
 	rank.calculateRankFiveRules()
 	rank.calculateRankEightElements()
+	rank.calculatePhonetics()
+	rank.calculateRankTotal()
 }
 
 func (rank *RankData) queryXinhua() {
@@ -344,7 +472,7 @@ func (rank *RankData) queryBaiJiaXing() {
 }
 
 func (rank *RankData) queryCommonName() {
-	v := list.QueryCommonNames(fmt.Sprintf("%s%s", rank.Name.Simplified.FamilyName.Str, rank.Name.Simplified.GivenName.Str))
+	v := list.QueryCommonNames(fmt.Sprintf("%s%s%s", rank.Name.Simplified.FamilyName.Str, rank.Name.Simplified.MiddleName.Str, rank.Name.Simplified.GivenName.Str))
 	if v > 0 {
 		rank.CommonName = true
 		rank.Name.IsCommon = true
@@ -356,7 +484,7 @@ func (rank *RankData) queryFamilyName() {
 }
 
 func (rank *RankData) queryPoetry() {
-	rank.Poetries = poetry.QueryPoetries(rank.Name.Simplified.GivenName.Str)
+	rank.Poetries = poetry.QueryPoetries(rank.Name.Simplified.MiddleName.Str + rank.Name.Simplified.GivenName.Str)
 	return
 }
 
@@ -375,8 +503,17 @@ func groupPinyin(pinyin []string) [][]string {
 	return group
 }
 
-// Rank : Rank name with birth time
-func Rank(language int, name *Name, birthTime int64, loc utils.Location) (*RankData, error) {
+// CalculateLuck : Luck pillars of given gender, and annual pillars of years from fromYear
+func (rank *RankData) CalculateLuck(gender, fromYear, years int) {
+	if rank.Calendar == nil {
+		return
+	}
+
+	rank.Luck = calculateLuck(rank.Calendar, gender, fromYear, years, rank.language)
+}
+
+// Rank : Rank name with birth time, ziHour for Zi hour convention of day pillar
+func Rank(language int, name *Name, birthTime int64, loc utils.Location, ziHour int) (*RankData, error) {
 	var (
 		rank        = &RankData{language: language, Name: name, Illegal: false}
 		pinyinGroup [][]string
@@ -385,6 +522,7 @@ func Rank(language int, name *Name, birthTime int64, loc utils.Location) (*RankD
 		commons     []string
 	)
 
+	name.localize(language)
 	pinyinGroup = groupPinyin(name.Pinyin)
 	for _, p := range pinyinGroup {
 		pinyin = strings.Join(p, ",")
@@ -401,18 +539,21 @@ func Rank(language int, name *Name, birthTime int64, loc utils.Location) (*RankD
 		}
 	}
 
-	rank.Calendar = calendar.New(birthTime, loc)
+	rank.Calendar = calendar.New(birthTime, loc, ziHour)
 	rank.Calendar.Ganzhi.YearString = rank.Calendar.Ganzhi.Year.String(rank.language)
 	rank.Calendar.Ganzhi.MonthString = rank.Calendar.Ganzhi.Month.String(rank.language)
 	rank.Calendar.Ganzhi.DayString = rank.Calendar.Ganzhi.Day.String(rank.language)
 	rank.Calendar.Ganzhi.HourString = rank.Calendar.Ganzhi.Hour.String(rank.language)
+	rank.Calendar.Ganzhi.ZiHourString = texts.GetAlias(texts.AliasZiHour, rank.Calendar.Ganzhi.ZiHour, rank.language)
 
-	// For Chinese, ignore middle name now
+	rank.StrokeSchool = name.StrokeSchool()
+	rank.StrokeSchoolAlias = texts.GetAlias(texts.AliasStrokeSchool, rank.StrokeSchool, rank.language)
 	rank.calculateFiveRules()
 	rank.calculateEightCharacters()
 	rank.calculateGanzhi()
 	rank.calculateSounds()
 	rank.calculateAnimal()
+	rank.calculateHexagrams()
 	rank.queryDictionaries()
 	rank.queryBaiJiaXing()
 	rank.queryCommonName()
@@ -0,0 +1,215 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file readings.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"yixuan_naming/dict"
+	"yixuan_naming/list"
+	"yixuan_naming/unihan"
+)
+
+// Sources of chosen reading
+const (
+	// ReadingDefault : First reading of unihan or dictionary
+	ReadingDefault = iota
+	// ReadingSpecial : Pinyin special list
+	ReadingSpecial
+	// ReadingSurname : Reading of family name
+	ReadingSurname
+	// ReadingName : Reading in given name context
+	ReadingName
+	// ReadingOverride : Reading given by caller
+	ReadingOverride
+)
+
+type reading struct {
+	Character   string   `json:"character"`
+	Candidates  []string `json:"candidates"`
+	Chosen      string   `json:"chosen"`
+	Source      int      `json:"source"`
+	SourceAlias string   `json:"source_alias"`
+}
+
+var (
+	toneStrips = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`ā|á|ǎ|à`), "a"},
+		{regexp.MustCompile(`ō|ó|ǒ|ò`), "o"},
+		{regexp.MustCompile(`ê|ē|é|ě|è`), "e"},
+		{regexp.MustCompile(`ī|í|ǐ|ì`), "i"},
+		{regexp.MustCompile(`ū|ú|ǔ|ù`), "u"},
+		{regexp.MustCompile(`ǖ|ǘ|ǚ|ǜ|ü`), "yu"},
+	}
+	readingSeparators = regexp.MustCompile(`[\s,;，；、/]+`)
+)
+
+// stripTone : Pinyin without tone marks
+func stripTone(pinyin string) string {
+	for _, s := range toneStrips {
+		pinyin = s.re.ReplaceAllString(pinyin, s.repl)
+	}
+
+	return pinyin
+}
+
+// candidateReadings : All readings of character, unihan first then XinHua dictionary
+func candidateReadings(c *unihan.HanCharacter) []string {
+	var (
+		ret  []string
+		seen = make(map[string]bool)
+	)
+
+	_add := func(p string) {
+		p = strings.TrimSpace(p)
+		if p != "" && p != "_" && !seen[p] {
+			seen[p] = true
+			ret = append(ret, p)
+		}
+	}
+
+	if c == nil {
+		return nil
+	}
+
+	if c.Readings != nil {
+		if c.Readings["kMandarin"] != nil {
+			for _, p := range strings.Fields(c.Readings["kMandarin"].Reading) {
+				_add(p)
+			}
+		}
+
+		// XianDaiHanYuCiDian & HanYuDaZiDian : location:reading,reading
+		for _, property := range []string{"kXHC1983", "kHanyuPinyin"} {
+			if c.Readings[property] != nil {
+				for _, group := range strings.Fields(c.Readings[property].Reading) {
+					parts := strings.Split(group, ":")
+					if len(parts) == 2 {
+						for _, p := range strings.Split(parts[1], ",") {
+							_add(p)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if x := dict.QueryXinhua(c.Unicode); x != nil {
+		for _, p := range readingSeparators.Split(x.Pinyin, -1) {
+			_add(p)
+		}
+	}
+
+	return ret
+}
+
+// knownReading : Reading is one of candidates, or listed in special and context lists
+func knownReading(c *unihan.HanCharacter, prev rune, candidates []string, p string) bool {
+	for _, v := range candidates {
+		if v == p {
+			return true
+		}
+	}
+
+	for _, v := range []string{list.QueryPinyinSpecial(c.Unicode), list.QuerySurnameReading(prev, c.Unicode), list.QueryNameReading(prev, c.Unicode)} {
+		if v != "" && v == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chooseReading : Resolve reading of character by override, context lists, special list and candidates.
+// Context lists are keyed by character and previous character (0 if none) of full name,
+// false if override is not a known reading of character
+func chooseReading(c *unihan.HanCharacter, prev rune, surname bool, override string) (reading, bool) {
+	ret := reading{Chosen: "_", Source: ReadingDefault}
+	if c == nil {
+		return ret, true
+	}
+
+	ret.Character = string(c.Unicode)
+	ret.Candidates = candidateReadings(c)
+	if len(ret.Candidates) > 0 {
+		ret.Chosen = ret.Candidates[0]
+	}
+
+	special := list.QueryPinyinSpecial(c.Unicode)
+	context := list.QueryNameReading(prev, c.Unicode)
+	if surname {
+		context = list.QuerySurnameReading(prev, c.Unicode)
+	}
+
+	valid := true
+	if override != "" && override != "_" && !knownReading(c, prev, ret.Candidates, override) {
+		override, valid = "", false
+	}
+
+	switch {
+	case override != "" && override != "_":
+		ret.Chosen, ret.Source = override, ReadingOverride
+	case context != "" && surname:
+		ret.Chosen, ret.Source = context, ReadingSurname
+	case context != "":
+		ret.Chosen, ret.Source = context, ReadingName
+	case special != "" && special != "_":
+		ret.Chosen, ret.Source = special, ReadingSpecial
+	}
+
+	return ret, valid
+}
+
+// OverrideReadings : Fix readings (pinyin with tone) of full name characters by position, "_" or empty to skip
+func (name *Name) OverrideReadings(readings []string) {
+	name.overrides = readings
+}
+
+// ReadingsError : Error of override readings unknown to characters, checked by Normalize
+func (name *Name) ReadingsError() error {
+	if len(name.invalidReadings) > 0 {
+		return fmt.Errorf("Unknown readings <%s> of name characters", strings.Join(name.invalidReadings, ","))
+	}
+
+	return nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,292 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file relations.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"yixuan_naming/texts"
+	"yixuan_naming/utils"
+)
+
+// Relations of tiangans & dizhis in four pillars
+const (
+	// RelationGanCombine : TianGan WuHe
+	RelationGanCombine = iota
+	// RelationSixCombine : LiuHe
+	RelationSixCombine
+	// RelationThreeCombine : SanHe
+	RelationThreeCombine
+	// RelationClash : LiuChong
+	RelationClash
+	// RelationPunishment : Xing
+	RelationPunishment
+	// RelationHarm : LiuHai
+	RelationHarm
+	// RelationBreak : LiuPo
+	RelationBreak
+)
+
+// Four pillars
+const (
+	// PillarYear : Year pillar
+	PillarYear = iota
+	// PillarMonth : Month pillar
+	PillarMonth
+	// PillarDay : Day pillar
+	PillarDay
+	// PillarHour : Hour pillar
+	PillarHour
+)
+
+var (
+	// Transformed element of gan combination, by smaller gan
+	ganCombineElements = []int{utils.ElementEarth, utils.ElementMetal, utils.ElementWater, utils.ElementWood, utils.ElementFire}
+
+	// Transformed element of six combination, by smaller zhi
+	sixCombineElements = map[int]int{
+		utils.ZhiZi:   utils.ElementEarth,
+		utils.ZhiYin:  utils.ElementWood,
+		utils.ZhiMao:  utils.ElementFire,
+		utils.ZhiChen: utils.ElementMetal,
+		utils.ZhiSi:   utils.ElementWater,
+		utils.ZhiWu:   utils.ElementEarth,
+	}
+
+	// Transformed element of three combination, by zhi % 4
+	threeCombineElements = []int{utils.ElementWater, utils.ElementMetal, utils.ElementFire, utils.ElementWood}
+
+	punishmentPairs = [][2]int{
+		{utils.ZhiYin, utils.ZhiSi}, {utils.ZhiSi, utils.ZhiShen}, {utils.ZhiShen, utils.ZhiYin},
+		{utils.ZhiChou, utils.ZhiXu}, {utils.ZhiXu, utils.ZhiWei}, {utils.ZhiWei, utils.ZhiChou},
+		{utils.ZhiZi, utils.ZhiMao},
+	}
+
+	// Self punishment
+	selfPunishments = []int{utils.ZhiChen, utils.ZhiWu, utils.ZhiYou, utils.ZhiHai}
+
+	breakPairs = [][2]int{
+		{utils.ZhiZi, utils.ZhiYou}, {utils.ZhiMao, utils.ZhiWu}, {utils.ZhiChen, utils.ZhiChou},
+		{utils.ZhiWei, utils.ZhiXu}, {utils.ZhiYin, utils.ZhiHai}, {utils.ZhiSi, utils.ZhiShen},
+	}
+)
+
+type relation struct {
+	values       []int
+	Type         int    `json:"type"`
+	TypeAlias    string `json:"type_alias"`
+	Stem         bool   `json:"stem"`
+	Pillars      []int  `json:"pillars"`
+	Characters   string `json:"characters"`
+	Element      int    `json:"element"`
+	ElementAlias string `json:"element_alias"`
+	Transformed  bool   `json:"transformed"`
+}
+
+func hasPair(pairs [][2]int, a, b int) bool {
+	for _, p := range pairs {
+		if (p[0] == a && p[1] == b) || (p[0] == b && p[1] == a) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// zhiConflicts : Clash, punishment, harm and break between two dizhis
+func zhiConflicts(a, b int) []int {
+	var ret []int
+	if (a-b+12)%12 == 6 {
+		ret = append(ret, RelationClash)
+	}
+
+	if hasPair(punishmentPairs, a, b) {
+		ret = append(ret, RelationPunishment)
+	}
+
+	if a == b {
+		for _, v := range selfPunishments {
+			if a == v {
+				ret = append(ret, RelationPunishment)
+			}
+		}
+	}
+
+	if (a+b)%12 == 7 {
+		ret = append(ret, RelationHarm)
+	}
+
+	if hasPair(breakPairs, a, b) {
+		ret = append(ret, RelationBreak)
+	}
+
+	return ret
+}
+
+// calculateRelations : Combinations, clashes, punishments, harms and breaks among four pillars.
+// Combinations transform when not clashed, and the month dizhi is of the transformed element
+// (adjacent pillars required except three combinations, day master never transforms)
+func (ec *eightCharacters) calculateRelations() {
+	var (
+		pillars = ec.pillars()
+		clashed = make([]bool, len(pillars))
+		month   = utils.ZhiFiveElement(ec.Month.DiZhi)
+	)
+
+	ec.Relations = nil
+	_add := func(t int, stem bool, element int, idx ...int) *relation {
+		r := &relation{Type: t, Stem: stem, Element: element, Pillars: idx}
+		for _, i := range idx {
+			if stem {
+				r.values = append(r.values, pillars[i].TianGan)
+			} else {
+				r.values = append(r.values, pillars[i].DiZhi)
+			}
+		}
+
+		ec.Relations = append(ec.Relations, r)
+
+		return r
+	}
+
+	for i := 0; i < len(pillars); i++ {
+		for j := i + 1; j < len(pillars); j++ {
+			for _, t := range zhiConflicts(pillars[i].DiZhi, pillars[j].DiZhi) {
+				_add(t, false, utils.ElementUnknown, i, j)
+				if t == RelationClash {
+					clashed[i] = true
+					clashed[j] = true
+				}
+			}
+		}
+	}
+
+	for i := 0; i < len(pillars); i++ {
+		for j := i + 1; j < len(pillars); j++ {
+			// Gan combination
+			a, b := pillars[i].TianGan, pillars[j].TianGan
+			if a != b && a%5 == b%5 {
+				r := _add(RelationGanCombine, true, ganCombineElements[a%5], i, j)
+				r.Transformed = j == i+1 && i != PillarDay && j != PillarDay && month == r.Element
+			}
+
+			// Six combination
+			a, b = pillars[i].DiZhi, pillars[j].DiZhi
+			if (a+b)%12 == 1 {
+				if a > b {
+					a = b
+				}
+
+				r := _add(RelationSixCombine, false, sixCombineElements[a], i, j)
+				r.Transformed = j == i+1 && !clashed[i] && !clashed[j] && month == r.Element
+			}
+		}
+	}
+
+	// Three combination, first of each zhi
+	for g, element := range threeCombineElements {
+		var idx []int
+		for _, zhi := range []int{g + 8, g, g + 4} {
+			for i, p := range pillars {
+				if p.DiZhi == zhi%12 {
+					idx = append(idx, i)
+					break
+				}
+			}
+		}
+
+		if len(idx) == 3 {
+			r := _add(RelationThreeCombine, false, element, idx...)
+			r.Transformed = !clashed[idx[0]] && !clashed[idx[1]] && !clashed[idx[2]]
+		}
+	}
+
+	// Effective five-elements of pillars, three combinations prevail
+	ec.elements = nil
+	for _, p := range pillars {
+		ec.elements = append(ec.elements, utils.GanFiveElement(p.TianGan), utils.ZhiFiveElement(p.DiZhi))
+	}
+
+	for _, t := range []int{RelationGanCombine, RelationSixCombine, RelationThreeCombine} {
+		for _, r := range ec.Relations {
+			if r.Type != t || !r.Transformed {
+				continue
+			}
+
+			for _, i := range r.Pillars {
+				if r.Stem {
+					ec.elements[i*2] = r.Element
+				} else {
+					ec.elements[i*2+1] = r.Element
+				}
+			}
+		}
+	}
+}
+
+// localizeRelations : Aliases of relations
+func (ec *eightCharacters) localizeRelations(language int) {
+	for _, r := range ec.Relations {
+		r.TypeAlias = texts.GetAlias(texts.AliasRelation, r.Type, language)
+		r.ElementAlias = texts.GetAlias(texts.AliasFiveElement, r.Element, language)
+		r.Characters = ""
+		for _, v := range r.values {
+			if r.Stem {
+				r.Characters += texts.GetAlias(texts.AliasGan, v, language)
+			} else {
+				r.Characters += texts.GetAlias(texts.AliasZhi, v, language)
+			}
+		}
+	}
+}
+
+// adjust : Five-elements total adjusted by transformed combinations
+func (spec *GanzhiFiveElementsSpec) adjust(ec *eightCharacters) {
+	pillars := ec.pillars()
+	spec.FiveElementsAdjusted = spec.FiveElementsTotal
+	for i, p := range pillars {
+		if e := ec.ganElement(i); e != utils.GanFiveElement(p.TianGan) {
+			spec.FiveElementsAdjusted.Add(utils.GanFiveElement(p.TianGan), -1)
+			spec.FiveElementsAdjusted.Add(e, 1)
+		}
+
+		if e := ec.zhiElement(i); e != utils.ZhiFiveElement(p.DiZhi) {
+			spec.FiveElementsAdjusted.Add(utils.ZhiFiveElement(p.DiZhi), -1)
+			spec.FiveElementsAdjusted.Add(e, 1)
+		}
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -0,0 +1,145 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file stars.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"yixuan_naming/list"
+	"yixuan_naming/texts"
+)
+
+type symbolicStar struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Base        int    `json:"base"`
+	Pillar      int    `json:"pillar"`
+	PillarAlias string `json:"pillar_alias"`
+	Pillars     []int  `json:"pillars"`
+}
+
+type void struct {
+	Pillar      int    `json:"pillar"`
+	PillarAlias string `json:"pillar_alias"`
+	Zhis        []int  `json:"zhis"`
+	ZhisAlias   string `json:"zhis_alias"`
+	Pillars     []int  `json:"pillars"`
+}
+
+// starBase : Pillar and value of symbolic star rule base
+func (ec *eightCharacters) starBase(base int) (int, int) {
+	switch base {
+	case list.StarBaseYearGan:
+		return PillarYear, ec.Year.TianGan
+	case list.StarBaseYearZhi:
+		return PillarYear, ec.Year.DiZhi
+	case list.StarBaseMonthZhi:
+		return PillarMonth, ec.Month.DiZhi
+	case list.StarBaseDayGan:
+		return PillarDay, ec.Day.TianGan
+	case list.StarBaseDayZhi:
+		return PillarDay, ec.Day.DiZhi
+	}
+
+	return -1, -1
+}
+
+// calculateSymbolicStars : Symbolic stars by loaded rules, and voids of year & day pillars
+func (ec *eightCharacters) calculateSymbolicStars() {
+	pillars := ec.pillars()
+	ec.Stars = nil
+	for _, rule := range list.SymbolicStarRules() {
+		for _, base := range rule.Bases {
+			pillar, value := ec.starBase(base)
+			if value < 0 || value >= len(rule.Table) {
+				continue
+			}
+
+			// Base never matches itself
+			self := list.StarBaseIsGan(base) == (rule.Target == list.StarTargetGan)
+			star := &symbolicStar{ID: rule.ID, Base: base, Pillar: pillar}
+			for i, p := range pillars {
+				if self && i == pillar {
+					continue
+				}
+
+				v := p.DiZhi
+				if rule.Target == list.StarTargetGan {
+					v = p.TianGan
+				}
+
+				for _, t := range rule.Table[value] {
+					if v == t {
+						star.Pillars = append(star.Pillars, i)
+						break
+					}
+				}
+			}
+
+			if len(star.Pillars) > 0 {
+				ec.Stars = append(ec.Stars, star)
+			}
+		}
+	}
+
+	ec.Voids = nil
+	for _, pillar := range []int{PillarYear, PillarDay} {
+		v := &void{Pillar: pillar, Zhis: pillars[pillar].Void()}
+		for i, p := range pillars {
+			if i != pillar && (p.DiZhi == v.Zhis[0] || p.DiZhi == v.Zhis[1]) {
+				v.Pillars = append(v.Pillars, i)
+			}
+		}
+
+		ec.Voids = append(ec.Voids, v)
+	}
+}
+
+// localizeSymbolicStars : Names and descriptions of symbolic stars, aliases of voids
+func (ec *eightCharacters) localizeSymbolicStars(language int) {
+	for _, star := range ec.Stars {
+		star.Name = texts.GetMessage(texts.MessageSymbolicStarName, star.ID, language)
+		star.Description = texts.GetMessage(texts.MessageSymbolicStarDescription, star.ID, language)
+		star.PillarAlias = texts.GetAlias(texts.AliasPillar, star.Pillar, language)
+	}
+
+	for _, v := range ec.Voids {
+		v.PillarAlias = texts.GetAlias(texts.AliasPillar, v.Pillar, language)
+		v.ZhisAlias = texts.GetAlias(texts.AliasZhi, v.Zhis[0], language) + texts.GetAlias(texts.AliasZhi, v.Zhis[1], language)
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
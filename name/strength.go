@@ -0,0 +1,258 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file strength.go
+ * @package name
+ * @since 10/17/2026
+ */
+
+package name
+
+import (
+	"sort"
+
+	"yixuan_naming/list"
+	"yixuan_naming/texts"
+	"yixuan_naming/unihan"
+	"yixuan_naming/utils"
+)
+
+// Strength levels of five-elements
+const (
+	// StrengthMissing : Element absent
+	StrengthMissing = iota
+	// StrengthWeak : Below WeakPercent
+	StrengthWeak
+	// StrengthBalanced : Between WeakPercent and StrongPercent
+	StrengthBalanced
+	// StrengthStrong : Above StrongPercent
+	StrengthStrong
+)
+
+const (
+	// MonthBranchWeight : Multiple of month dizhi (YueLing) weight
+	MonthBranchWeight = 2
+	// WeakPercent : Element weaker than this percent of total is weak
+	WeakPercent = 12
+	// StrongPercent : Element stronger than this percent of total is strong
+	StrongPercent = 30
+)
+
+var (
+	// Percent factors of elements by relation to month dizhi element : Wang, Xiang, Xiu, Qiu, Si
+	seasonFactors = map[int]int{
+		utils.FiveElementEqual:   120,
+		utils.FiveElementBirthed: 110,
+		utils.FiveElementBirth:   100,
+		utils.FiveElementKill:    90,
+		utils.FiveElementKilled:  80,
+	}
+
+	// Percent factors of rooted and unrooted tiangans
+	rootedFactor   = 120
+	unrootedFactor = 80
+
+	// Fill scores of name characters by strength level of their elements
+	fillScores = []int{100, 60, 20, 0}
+)
+
+type elementStrength struct {
+	Element    int    `json:"element"`
+	Alias      string `json:"alias"`
+	Score      int    `json:"score"`
+	Percent    int    `json:"percent"`
+	Level      int    `json:"level"`
+	LevelAlias string `json:"level_alias"`
+}
+
+type elementFill struct {
+	Elements      []int  `json:"elements"`
+	ElementsAlias string `json:"elements_alias"`
+	Fills         []int  `json:"fills"`
+	FillsAlias    string `json:"fills_alias"`
+	Score         int    `json:"score"`
+}
+
+// characterElement : Five-element of character, simplified form as fallback
+func characterElement(r rune) int {
+	fe := list.QueryFiveElement(r)
+	if fe == utils.ElementUnknown {
+		c, _ := unihan.Query(r)
+		if c != nil {
+			rs, _ := c.QuerySimplifiedPrefer()
+			if rs > 0 && rs != r {
+				fe = list.QueryFiveElement(rs)
+			}
+		}
+	}
+
+	return fe
+}
+
+// weigh : Weighted five-element strengths by month order (YueLing & WangXiangXiuQiuSi), hidden tiangan
+// proportions and roots of tiangans, with deficits (missing or weak elements, weakest first)
+func (spec *GanzhiFiveElementsSpec) weigh(ec *eightCharacters, language int) {
+	var (
+		pillars = ec.pillars()
+		scores  = make([]int, 5)
+		total   int
+	)
+
+	for i, p := range pillars {
+		mult := 1
+		if i == PillarMonth {
+			mult = MonthBranchWeight
+		}
+
+		// Dizhi, whole weight to transformed element
+		if e := ec.zhiElement(i); e != utils.ZhiFiveElement(p.DiZhi) {
+			scores[e] += 100 * mult
+		} else {
+			for _, h := range utils.ZhiHiddenGans(p.DiZhi) {
+				scores[utils.GanFiveElement(h.Gan)] += h.Weight * mult
+			}
+		}
+
+		// Tiangan, rooted if any dizhi holds its element
+		e := ec.ganElement(i)
+		factor := unrootedFactor
+		for j, q := range pillars {
+			if ec.zhiElement(j) == e {
+				factor = rootedFactor
+			}
+
+			for _, h := range utils.ZhiHiddenGans(q.DiZhi) {
+				if utils.GanFiveElement(h.Gan) == e {
+					factor = rootedFactor
+				}
+			}
+		}
+
+		scores[e] += 100 * factor / 100
+	}
+
+	month := ec.zhiElement(PillarMonth)
+	for e := range scores {
+		scores[e] = scores[e] * seasonFactors[utils.CompareFiveElements(e, month)] / 100
+		total += scores[e]
+	}
+
+	spec.Strengths = nil
+	spec.Deficits = nil
+	spec.DeficitsAlias = ""
+	for e, score := range scores {
+		s := &elementStrength{
+			Element: e,
+			Alias:   texts.GetAlias(texts.AliasFiveElement, e, language),
+			Score:   score,
+		}
+
+		if total > 0 {
+			s.Percent = score * 100 / total
+		}
+
+		switch {
+		case score == 0:
+			s.Level = StrengthMissing
+		case s.Percent < WeakPercent:
+			s.Level = StrengthWeak
+		case s.Percent > StrongPercent:
+			s.Level = StrengthStrong
+		default:
+			s.Level = StrengthBalanced
+		}
+
+		s.LevelAlias = texts.GetAlias(texts.AliasStrengthLevel, s.Level, language)
+		spec.Strengths = append(spec.Strengths, s)
+		if s.Level == StrengthMissing || s.Level == StrengthWeak {
+			spec.Deficits = append(spec.Deficits, e)
+		}
+	}
+
+	sort.SliceStable(spec.Deficits, func(i, j int) bool {
+		return scores[spec.Deficits[i]] < scores[spec.Deficits[j]]
+	})
+
+	for _, e := range spec.Deficits {
+		spec.DeficitsAlias += texts.GetAlias(texts.AliasFiveElement, e, language)
+	}
+}
+
+// fillScore : How single character fills deficits of weighted five-elements
+func (spec *GanzhiFiveElementsSpec) fillScore(r rune) int {
+	e := characterElement(r)
+	if e < utils.ElementWood || e > utils.ElementWater || len(spec.Strengths) == 0 {
+		return 0
+	}
+
+	return fillScores[spec.Strengths[e].Level]
+}
+
+// fill : How characters of given name fill deficits of weighted five-elements
+func (spec *GanzhiFiveElementsSpec) fill(runes []rune) *elementFill {
+	ret := &elementFill{}
+	if len(runes) == 0 || len(spec.Strengths) == 0 {
+		return ret
+	}
+
+	for _, r := range runes {
+		e := characterElement(r)
+		ret.Elements = append(ret.Elements, e)
+		if e < utils.ElementWood || e > utils.ElementWater {
+			continue
+		}
+
+		level := spec.Strengths[e].Level
+		ret.Score += fillScores[level]
+		if level == StrengthMissing || level == StrengthWeak {
+			ret.Fills = append(ret.Fills, e)
+		}
+	}
+
+	ret.Score /= len(runes)
+
+	return ret
+}
+
+// localize : Aliases of elements
+func (f *elementFill) localize(language int) {
+	f.ElementsAlias = ""
+	f.FillsAlias = ""
+	for _, e := range f.Elements {
+		f.ElementsAlias += texts.GetAlias(texts.AliasFiveElement, e, language)
+	}
+
+	for _, e := range f.Fills {
+		f.FillsAlias += texts.GetAlias(texts.AliasFiveElement, e, language)
+	}
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -32,6 +32,16 @@ package name
 
 import (
 	"yixuan_naming/texts"
+	"yixuan_naming/utils"
+)
+
+const (
+	// TenGods : Number of ten gods
+	TenGods = 10
+	// TenGodDayMaster : Day master (RiZhu) itself, not a ten god
+	TenGodDayMaster = 10
+	// stemWeight : Weight of visible tiangan, as hidden one of single main qi
+	stemWeight = 100
 )
 
 type tenGod struct {
@@ -51,6 +61,91 @@ func getTenGod(index int, language int) *tenGod {
 	return ret
 }
 
+type stemGod struct {
+	Gan      int    `json:"gan"`
+	GanAlias string `json:"gan_alias"`
+	God      int    `json:"god"`
+	GodAlias string `json:"god_alias"`
+	Weight   int    `json:"weight"`
+}
+
+type pillarGods struct {
+	Stem   *stemGod   `json:"stem"`
+	Hidden []*stemGod `json:"hidden"`
+}
+
+type tenGodStat struct {
+	God      int    `json:"god"`
+	GodAlias string `json:"god_alias"`
+	Count    int    `json:"count"`
+	Strength int    `json:"strength"`
+}
+
+type tenGods struct {
+	Year  pillarGods    `json:"year"`
+	Month pillarGods    `json:"month"`
+	Day   pillarGods    `json:"day"`
+	Hour  pillarGods    `json:"hour"`
+	Stats []*tenGodStat `json:"stats"`
+}
+
+func newStemGod(self, gan, weight, language int) *stemGod {
+	return &stemGod{
+		Gan:      gan,
+		GanAlias: texts.GetAlias(texts.AliasGan, gan, language),
+		God:      utils.CompareGan(self, gan),
+		GodAlias: texts.GetAlias(texts.AliasTenGod, utils.CompareGan(self, gan), language),
+		Weight:   weight,
+	}
+}
+
+// calculateTenGods : Ten gods of tiangans and hidden tiangans relative to day master, with counts and strengths
+func (ec *eightCharacters) calculateTenGods(language int) {
+	var (
+		ret  tenGods
+		self = ec.Day.TianGan
+	)
+
+	ret.Stats = make([]*tenGodStat, TenGods)
+	for i := range ret.Stats {
+		ret.Stats[i] = &tenGodStat{
+			God:      i,
+			GodAlias: texts.GetAlias(texts.AliasTenGod, i, language),
+		}
+	}
+
+	_stat := func(g *stemGod) {
+		ret.Stats[g.God].Count++
+		ret.Stats[g.God].Strength += g.Weight
+	}
+
+	for _, p := range []struct {
+		gz   *utils.GanzhiPair
+		gods *pillarGods
+	}{
+		{ec.Year, &ret.Year},
+		{ec.Month, &ret.Month},
+		{ec.Day, &ret.Day},
+		{ec.Hour, &ret.Hour},
+	} {
+		p.gods.Stem = newStemGod(self, p.gz.TianGan, stemWeight, language)
+		if p.gz == ec.Day {
+			p.gods.Stem.God = TenGodDayMaster
+			p.gods.Stem.GodAlias = texts.GetAlias(texts.AliasTenGod, TenGodDayMaster, language)
+		} else {
+			_stat(p.gods.Stem)
+		}
+
+		for _, h := range utils.ZhiHiddenGans(p.gz.DiZhi) {
+			g := newStemGod(self, h.Gan, h.Weight, language)
+			p.gods.Hidden = append(p.gods.Hidden, g)
+			_stat(g)
+		}
+	}
+
+	ec.TenGods = ret
+}
+
 /*
  * Local variables:
  * tab-width: 4
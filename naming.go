@@ -31,10 +31,14 @@
 package main
 
 import (
+	"errors"
+	"os"
+	"yixuan_naming/calendar"
 	"yixuan_naming/common"
 	"yixuan_naming/dict"
 	"yixuan_naming/list"
 	"yixuan_naming/name"
+	"yixuan_naming/place"
 	"yixuan_naming/poetry"
 	"yixuan_naming/texts"
 	"yixuan_naming/unihan"
@@ -47,6 +51,10 @@ const (
 	DefaultLibraryPath string = "/usr/share/naming"
 	// DefaultLanguage : Default message language
 	DefaultLanguage = texts.LanguageSimplified
+	// DefaultLongitude : Longitude of birth location not specified
+	DefaultLongitude = 120.0
+	// DefaultLatitude : Latitude of birth location not specified
+	DefaultLatitude = 45.0
 )
 
 var (
@@ -58,9 +66,18 @@ func main() {
 	g.Config.SetDefault("HTTP_Listen_Address", DefaultHTTPListenAddr)
 	g.Config.SetDefault("Library_Path", DefaultLibraryPath)
 	g.Config.SetDefault("Default_language", DefaultLanguage)
+	g.Config.SetDefault("Zi_Hour_Convention", calendar.ZiHourChangeDay)
+	g.Config.SetDefault("Stroke_School", "kumazaki")
 	texts.LanguageDefault = g.Config.GetInt("Default_language")
 
 	g.Logger.Printf("Start server")
+	if v := g.Config.GetInt("Zi_Hour_Convention"); v != calendar.ZiHourChangeDay && v != calendar.ZiHourLateZi {
+		g.Logger.Fatalf("Invalid Zi_Hour_Convention %d", v)
+	}
+
+	if _, ok := list.AssertStrokeSchool(g.Config.GetString("Stroke_School")); !ok {
+		g.Logger.Fatalf("Invalid Stroke_School %s", g.Config.GetString("Stroke_School"))
+	}
 
 	var (
 		lines, linePoetries, lineWords int
@@ -91,9 +108,6 @@ func main() {
 		g.Logger.Printf("Load %d lines from common character list to common characters L2", lines)
 	}
 
-	lines = list.PrepareCommonCharacters()
-	g.Logger.Printf("Prepared %d characters from common character lists", lines)
-
 	// TraditionalSpecial
 	lines, err = list.LoadTraditionalSpecial(g.Config.GetString("Library_Path"))
 	if err != nil {
@@ -110,6 +124,25 @@ func main() {
 		g.Logger.Printf("Load %d lines from pinyin special list", lines)
 	}
 
+	// Readings of polyphonic characters
+	lines, err = list.LoadSurnameReadings(g.Config.GetString("Library_Path"))
+	if errors.Is(err, os.ErrNotExist) {
+		g.Logger.Printf("Surname reading list not found, skipped")
+	} else if err != nil {
+		g.Logger.Fatal(err)
+	} else {
+		g.Logger.Printf("Load %d lines from surname reading list", lines)
+	}
+
+	lines, err = list.LoadNameReadings(g.Config.GetString("Library_Path"))
+	if errors.Is(err, os.ErrNotExist) {
+		g.Logger.Printf("Name reading list not found, skipped")
+	} else if err != nil {
+		g.Logger.Fatal(err)
+	} else {
+		g.Logger.Printf("Load %d lines from name reading list", lines)
+	}
+
 	// StrokeSpecial
 	lines, err = list.LoadStrokeSpecial(g.Config.GetString("Library_Path"))
 	if err != nil {
@@ -118,6 +151,10 @@ func main() {
 		g.Logger.Printf("Load %d lines from stroke special list", lines)
 	}
 
+	// Common characters by strokes of each school, after special lists
+	lines = list.PrepareCommonCharacters()
+	g.Logger.Printf("Prepared %d characters from common character lists", lines)
+
 	// Common words
 	lines, err = list.LoadCommon(g.Config.GetString("Library_Path"))
 	if err != nil {
@@ -158,6 +195,26 @@ func main() {
 		g.Logger.Printf("Load %d lines from character five elements list", lines)
 	}
 
+	// Character genders
+	lines, err = list.LoadCharacterGenders(g.Config.GetString("Library_Path"))
+	if errors.Is(err, os.ErrNotExist) {
+		g.Logger.Printf("Character gender lexicon not found, all characters neutral")
+	} else if err != nil {
+		g.Logger.Fatal(err)
+	} else {
+		g.Logger.Printf("Load %d lines from character gender lexicon", lines)
+	}
+
+	// Component radicals
+	lines, err = list.LoadRadicalComponents(g.Config.GetString("Library_Path"))
+	if errors.Is(err, os.ErrNotExist) {
+		g.Logger.Printf("Radical components not found, indexing radicals only")
+	} else if err != nil {
+		g.Logger.Fatal(err)
+	} else {
+		g.Logger.Printf("Load %d lines from radical components", lines)
+	}
+
 	// BaiJiaXing
 	lines, err = list.LoadBaiJiaXing(g.Config.GetString("Library_Path"))
 	if err != nil {
@@ -166,6 +223,16 @@ func main() {
 		g.Logger.Printf("Load %d lines from baijiaxing", lines)
 	}
 
+	// Symbolic stars
+	lines, err = list.LoadSymbolicStars(g.Config.GetString("Library_Path"))
+	if errors.Is(err, os.ErrNotExist) {
+		g.Logger.Printf("Symbolic star rules not found, no stars reported")
+	} else if err != nil {
+		g.Logger.Fatal(err)
+	} else {
+		g.Logger.Printf("Load %d rules of symbolic stars", lines)
+	}
+
 	// Character dictionaries
 	lines, err = dict.LoadXinhua(g.Config.GetString("Library_Path"))
 	if err != nil {
@@ -181,6 +248,16 @@ func main() {
 		g.Logger.Printf("Load %d lines from dictionary Folkways", lines)
 	}
 
+	// Gazetteer
+	lines, err = place.LoadPlaces(g.Config.GetString("Library_Path"))
+	if errors.Is(err, os.ErrNotExist) {
+		g.Logger.Printf("Gazetteer not found, birth_place and place search disabled")
+	} else if err != nil {
+		g.Logger.Fatal(err)
+	} else {
+		g.Logger.Printf("Load %d places from gazetteer", lines)
+	}
+
 	// Messages
 	lines, err = texts.LoadMessages(g.Config.GetString("Library_Path"))
 	if err != nil {
@@ -197,6 +274,19 @@ func main() {
 		g.Logger.Printf("Load %d poetries, %d words", linePoetries, lineWords)
 	}
 
+	// Scoring profile
+	profile := name.DefaultScoringProfile()
+	err = g.Config.UnmarshalKey("Scoring_Profile", profile)
+	if err == nil {
+		err = name.SetScoringProfile(profile)
+	}
+
+	if err != nil {
+		g.Logger.Fatal(err)
+	} else {
+		g.Logger.Printf("Scoring profile <%s> loaded", profile.Name)
+	}
+
 	err = name.FillRankTable()
 	if err != nil {
 		g.Logger.Fatal(err)
@@ -0,0 +1,250 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2019 HereweTech Co.LTD
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of
+ * this software and associated documentation files (the "Software"), to deal in
+ * the Software without restriction, including without limitation the rights to
+ * use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+ * the Software, and to permit persons to whom the Software is furnished to do so,
+ * subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+ * FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+ * COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+ * IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+ * CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+/**
+ * @file place.go
+ * @package place
+ * @since 10/17/2026
+ */
+
+package place
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"yixuan_naming/utils"
+)
+
+// Place levels
+const (
+	// LevelCountry : Country
+	LevelCountry = iota
+	// LevelProvince : Province, municipality or autonomous region
+	LevelProvince
+	// LevelCity : Prefecture-level city
+	LevelCity
+	// LevelCounty : County or district
+	LevelCounty
+	// LevelWorldCity : Major city outside China
+	LevelWorldCity
+)
+
+// Place : Item of gazetteer
+type Place struct {
+	Code      string   `json:"code"`
+	Parent    string   `json:"parent"`
+	Level     int      `json:"level"`
+	Name      string   `json:"name"`
+	FullName  string   `json:"full_name"`
+	Aliases   []string `json:"aliases,omitempty"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Zone      string   `json:"zone"`
+}
+
+var (
+	placesA []*Place
+	placesM map[string][]*Place
+
+	// Administrative suffixes stripped from queries
+	placeSuffixes = []string{"特别行政区", "自治区", "自治州", "自治县", "省", "市", "县", "区", "州", "盟", "旗"}
+)
+
+// Location : Geographic location of place
+func (p *Place) Location() utils.Location {
+	return utils.Location{
+		Latitude:  p.Latitude,
+		Longitude: p.Longitude,
+		Zone:      p.Zone,
+	}
+}
+
+func normalizeKey(input string) string {
+	key := strings.ToLower(strings.TrimSpace(input))
+	for _, s := range placeSuffixes {
+		if strings.HasSuffix(key, s) && len(key) > len(s) {
+			return strings.TrimSuffix(key, s)
+		}
+	}
+
+	return key
+}
+
+// Query : Find place by name, full name or alias. Higher levels prefered for duplicated names
+func Query(input string) *Place {
+	if placesM == nil {
+		return nil
+	}
+
+	list := placesM[strings.ToLower(strings.TrimSpace(input))]
+	if len(list) == 0 {
+		list = placesM[normalizeKey(input)]
+	}
+
+	if len(list) == 0 {
+		return nil
+	}
+
+	return list[0]
+}
+
+// Search : Places with name, full name or alias starting with input
+func Search(input string, limit int) []*Place {
+	var (
+		ret  []*Place
+		seen = make(map[*Place]bool)
+		key  = strings.ToLower(strings.TrimSpace(input))
+	)
+
+	if key == "" || placesA == nil {
+		return nil
+	}
+
+	for _, p := range placesA {
+		if seen[p] {
+			continue
+		}
+
+		for _, k := range append([]string{p.Name, p.FullName}, p.Aliases...) {
+			if strings.HasPrefix(strings.ToLower(k), key) {
+				seen[p] = true
+				ret = append(ret, p)
+				break
+			}
+		}
+
+		if limit > 0 && len(ret) >= limit {
+			break
+		}
+	}
+
+	return ret
+}
+
+// LoadPlaces : Load gazetteer
+// Line format : code|parent|level|name|full_name|aliases(comma separated)|latitude|longitude|zone
+func LoadPlaces(dir string) (int, error) {
+	var (
+		fullPath string
+		f        *os.File
+		err      error
+		scanner  *bufio.Scanner
+		line     string
+		parts    []string
+		p        *Place
+		total    int
+		zones    = make(map[string]bool)
+	)
+
+	placesA = nil
+	placesM = make(map[string][]*Place)
+	fullPath = fmt.Sprintf("%s/place/Places.txt", dir)
+	f, err = os.Open(fullPath)
+	if err != nil {
+		placesM = nil
+		return 0, fmt.Errorf("Load gazetteer file <%s> failed : %w", fullPath, err)
+	}
+
+	scanner = bufio.NewScanner(f)
+	for scanner.Scan() == true {
+		line = scanner.Text()
+		parts = strings.Split(line, "|")
+		if 9 != len(parts) {
+			continue
+		}
+
+		p = &Place{
+			Code:     parts[0],
+			Parent:   parts[1],
+			Name:     parts[3],
+			FullName: parts[4],
+			Zone:     parts[8],
+		}
+
+		p.Level, err = strconv.Atoi(parts[2])
+		if err != nil {
+			continue
+		}
+
+		p.Latitude, err = strconv.ParseFloat(parts[6], 64)
+		if err != nil || p.Latitude < -90 || p.Latitude > 90 {
+			continue
+		}
+
+		p.Longitude, err = strconv.ParseFloat(parts[7], 64)
+		if err != nil || p.Longitude < -180 || p.Longitude > 180 {
+			continue
+		}
+
+		// IANA zone, checked once per zone
+		if _, ok := zones[p.Zone]; !ok {
+			_, err = time.LoadLocation(p.Zone)
+			zones[p.Zone] = p.Zone != "" && err == nil
+		}
+
+		if !zones[p.Zone] {
+			continue
+		}
+
+		if parts[5] != "" {
+			p.Aliases = strings.Split(parts[5], ",")
+		}
+
+		placesA = append(placesA, p)
+		total++
+	}
+
+	f.Close()
+
+	// Higher levels first
+	sort.SliceStable(placesA, func(i, j int) bool {
+		return placesA[i].Level < placesA[j].Level
+	})
+
+	for _, p = range placesA {
+		keys := map[string]bool{}
+		for _, k := range append([]string{p.Name, p.FullName, normalizeKey(p.FullName)}, p.Aliases...) {
+			k = strings.ToLower(k)
+			if k != "" && !keys[k] {
+				keys[k] = true
+				placesM[k] = append(placesM[k], p)
+			}
+		}
+	}
+
+	return total, nil
+}
+
+/*
+ * Local variables:
+ * tab-width: 4
+ * c-basic-offset: 4
+ * End:
+ * vim600: sw=4 ts=4 fdm=marker
+ * vim<600: sw=4 ts=4
+ */
@@ -63,6 +63,40 @@ const (
 	AliasSolarterm
 	// AliasSoundFiveElement : 15
 	AliasSoundFiveElement
+	// AliasFiveElementRelation : 16
+	AliasFiveElementRelation
+	// AliasGenderTag : 17
+	AliasGenderTag
+	// AliasToneClass : 18
+	AliasToneClass
+	// AliasPhoneticIssue : 19
+	AliasPhoneticIssue
+	// AliasReadingSource : 20
+	AliasReadingSource
+	// AliasZiHour : 21
+	AliasZiHour
+	// AliasLuckDirection : 22
+	AliasLuckDirection
+	// AliasRelation : 23
+	AliasRelation
+	// AliasPillar : 24
+	AliasPillar
+	// AliasReasonStep : 25
+	AliasReasonStep
+	// AliasSeason : 26
+	AliasSeason
+	// AliasClimate : 27
+	AliasClimate
+	// AliasStrength : 28
+	AliasStrength
+	// AliasYesNo : 29
+	AliasYesNo
+	// AliasFamilyRole : 30
+	AliasFamilyRole
+	// AliasStrengthLevel : 31
+	AliasStrengthLevel
+	// AliasStrokeSchool : 32
+	AliasStrokeSchool
 )
 
 // Aliases
@@ -100,8 +134,8 @@ var (
 		{"甲木", "乙木", "丙火", "丁火", "戊土", "己土", "庚金", "辛金", "壬水", "癸水"},
 	}
 	tenGodAliases = [][]string{
-		{"比肩", "劫财", "食神", "伤官", "偏财", "正财", "七杀", "正官", "偏印", "正印"},
-		{"比肩", "劫財", "食神", "傷官", "偏財", "正財", "七殺", "正官", "偏印", "正印"},
+		{"比肩", "劫财", "食神", "伤官", "偏财", "正财", "七杀", "正官", "偏印", "正印", "日主"},
+		{"比肩", "劫財", "食神", "傷官", "偏財", "正財", "七殺", "正官", "偏印", "正印", "日主"},
 	}
 	tenGodRpresentativeAliases = [][]string{
 		{"兄弟", "兄弟", "子孙", "子孙", "妻财", "妻财", "官鬼", "官鬼", "父母", "父母"},
@@ -161,6 +195,74 @@ var (
 			"桑柘木", "大溪水", "砂中土", "天上火", "石榴木", "大海水",
 		},
 	}
+	fiveElementRelationAliases = [][]string{
+		{"相同", "相克", "被克", "相生", "被生"},
+		{"相同", "相剋", "被剋", "相生", "被生"},
+	}
+	genderTagAliases = [][]string{
+		{"中性", "阳刚", "阴柔"},
+		{"中性", "陽剛", "陰柔"},
+	}
+	toneClassAliases = [][]string{
+		{"未知", "平", "仄", "轻"},
+		{"未知", "平", "仄", "輕"},
+	}
+	phoneticIssueAliases = [][]string{
+		{"全平", "全仄", "声调重复", "双声", "叠韵", "连读拗口"},
+		{"全平", "全仄", "聲調重複", "雙聲", "疊韻", "連讀拗口"},
+	}
+	readingSourceAliases = [][]string{
+		{"默认", "特殊", "姓氏", "人名", "指定"},
+		{"默認", "特殊", "姓氏", "人名", "指定"},
+	}
+	ziHourAliases = [][]string{
+		{"子初换日", "早晚子时"},
+		{"子初換日", "早晚子時"},
+	}
+	luckDirectionAliases = [][]string{
+		{"逆行", "顺行"},
+		{"逆行", "順行"},
+	}
+	relationAliases = [][]string{
+		{"天干五合", "六合", "三合", "六冲", "相刑", "六害", "六破"},
+		{"天干五合", "六合", "三合", "六沖", "相刑", "六害", "六破"},
+	}
+	pillarAliases = [][]string{
+		{"年柱", "月柱", "日柱", "时柱"},
+		{"年柱", "月柱", "日柱", "時柱"},
+	}
+	reasonStepAliases = [][]string{
+		{"月令", "得势", "得地", "身强弱", "强弱喜用", "季节", "寒暖燥湿", "调候所需", "原局已有", "调候喜用"},
+		{"月令", "得勢", "得地", "身強弱", "強弱喜用", "季節", "寒暖燥濕", "調候所需", "原局已有", "調候喜用"},
+	}
+	seasonAliases = [][]string{
+		{"春", "夏", "秋", "冬"},
+		{"春", "夏", "秋", "冬"},
+	}
+	climateAliases = [][]string{
+		{"平和", "炎热", "干燥", "寒冷"},
+		{"平和", "炎熱", "乾燥", "寒冷"},
+	}
+	strengthAliases = [][]string{
+		{"身弱", "身强"},
+		{"身弱", "身強"},
+	}
+	yesNoAliases = [][]string{
+		{"否", "是"},
+		{"否", "是"},
+	}
+	familyRoleAliases = [][]string{
+		{"孩子", "父亲", "母亲", "兄弟姐妹"},
+		{"孩子", "父親", "母親", "兄弟姐妹"},
+	}
+	strengthLevelAliases = [][]string{
+		{"缺", "弱", "平", "旺"},
+		{"缺", "弱", "平", "旺"},
+	}
+	strokeSchoolAliases = [][]string{
+		{"熊崎式", "康熙笔画", "简体笔画"},
+		{"熊崎式", "康熙筆畫", "簡體筆畫"},
+	}
 )
 
 // GetAlias : Get aliases text
@@ -200,6 +302,40 @@ func GetAlias(alias int, index int, language int) string {
 		aliases = solartermAliases
 	case AliasSoundFiveElement:
 		aliases = soundFiveElementAliases
+	case AliasFiveElementRelation:
+		aliases = fiveElementRelationAliases
+	case AliasGenderTag:
+		aliases = genderTagAliases
+	case AliasToneClass:
+		aliases = toneClassAliases
+	case AliasPhoneticIssue:
+		aliases = phoneticIssueAliases
+	case AliasReadingSource:
+		aliases = readingSourceAliases
+	case AliasZiHour:
+		aliases = ziHourAliases
+	case AliasLuckDirection:
+		aliases = luckDirectionAliases
+	case AliasRelation:
+		aliases = relationAliases
+	case AliasPillar:
+		aliases = pillarAliases
+	case AliasReasonStep:
+		aliases = reasonStepAliases
+	case AliasSeason:
+		aliases = seasonAliases
+	case AliasClimate:
+		aliases = climateAliases
+	case AliasStrength:
+		aliases = strengthAliases
+	case AliasYesNo:
+		aliases = yesNoAliases
+	case AliasFamilyRole:
+		aliases = familyRoleAliases
+	case AliasStrengthLevel:
+		aliases = strengthLevelAliases
+	case AliasStrokeSchool:
+		aliases = strokeSchoolAliases
 	}
 
 	if aliases == nil || len(aliases) < 1 {
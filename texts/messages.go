@@ -55,6 +55,18 @@ const (
 	MessageAnimalYear
 	// MessageAnimalRadicalsDescription : 8
 	MessageAnimalRadicalsDescription
+	// MessageElementFitDescription : 9
+	MessageElementFitDescription
+	// MessageSymbolicStarName : 10
+	MessageSymbolicStarName
+	// MessageSymbolicStarDescription : 11
+	MessageSymbolicStarDescription
+	// MessageHexagramName : 12
+	MessageHexagramName
+	// MessageHexagramDescription : 13
+	MessageHexagramDescription
+	// MessageHexagramLine : 14
+	MessageHexagramLine
 )
 
 var (
@@ -67,6 +79,12 @@ var (
 	threeElementDescriptionMessages     [][]string
 	animalYearMessages                  [][]string
 	animalRadicalsDescriptions          [][]string
+	elementFitDescriptions              [][]string
+	symbolicStarNames                   [][]string
+	symbolicStarDescriptions            [][]string
+	hexagramNames                       [][]string
+	hexagramDescriptions                [][]string
+	hexagramLines                       [][]string
 )
 
 // GetMessage : Get message from list
@@ -92,6 +110,18 @@ func GetMessage(message int, index int, language int) string {
 		messages = animalYearMessages
 	case MessageAnimalRadicalsDescription:
 		messages = animalRadicalsDescriptions
+	case MessageElementFitDescription:
+		messages = elementFitDescriptions
+	case MessageSymbolicStarName:
+		messages = symbolicStarNames
+	case MessageSymbolicStarDescription:
+		messages = symbolicStarDescriptions
+	case MessageHexagramName:
+		messages = hexagramNames
+	case MessageHexagramDescription:
+		messages = hexagramDescriptions
+	case MessageHexagramLine:
+		messages = hexagramLines
 	}
 
 	if messages == nil || len(messages) < 1 {
@@ -134,6 +164,18 @@ func LoadMessages(dir string) (int, error) {
 	total += cLines
 	cLines, animalRadicalsDescriptions = loadMessage(dir, "AnimalRadicalsDescriptions")
 	total += cLines
+	cLines, elementFitDescriptions = loadMessage(dir, "ElementFitDescriptions")
+	total += cLines
+	cLines, symbolicStarNames = loadMessage(dir, "SymbolicStarNames")
+	total += cLines
+	cLines, symbolicStarDescriptions = loadMessage(dir, "SymbolicStarDescriptions")
+	total += cLines
+	cLines, hexagramNames = loadMessage(dir, "HexagramNames")
+	total += cLines
+	cLines, hexagramDescriptions = loadMessage(dir, "HexagramDescriptions")
+	total += cLines
+	cLines, hexagramLines = loadMessage(dir, "HexagramLines")
+	total += cLines
 
 	return total, nil
 }
@@ -36,6 +36,7 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"yixuan_naming/utils"
 )
@@ -481,6 +482,59 @@ func (c *HanCharacter) QuerySimplifiedPrefer() (rune, error) {
 	return tsi[0], nil
 }
 
+// QueryTotalStrokes : Query total strokes, simplified (G source) first and traditional (T source) last if differ
+func (c *HanCharacter) QueryTotalStrokes() []int {
+	var ret []int
+	if c.DictionaryLikeDatas == nil || c.DictionaryLikeDatas["kTotalStrokes"] == nil {
+		return nil
+	}
+
+	for _, v := range strings.Fields(c.DictionaryLikeDatas["kTotalStrokes"].Data) {
+		stroke, err := strconv.Atoi(v)
+		if err == nil && stroke > 0 {
+			ret = append(ret, stroke)
+		}
+	}
+
+	return ret
+}
+
+// QueryRadicals : Query Kangxi radicals (1 - 214) of unicode & kangxi radical-stroke, simplified radicals folded
+func (c *HanCharacter) QueryRadicals() []int {
+	var ret []int
+	if c.RadicalStrokeCounts == nil {
+		return nil
+	}
+
+	for _, property := range []string{"kRSUnicode", "kRSKangXi"} {
+		if c.RadicalStrokeCounts[property] == nil {
+			continue
+		}
+
+		rss := regexp.MustCompile(`([0-9]+)'*\.[0-9]+`).FindAllStringSubmatch(c.RadicalStrokeCounts[property].RadicalStroke, -1)
+		for _, rs := range rss {
+			radical, _ := strconv.Atoi(rs[1])
+			if radical < 1 || radical > 214 {
+				continue
+			}
+
+			found := false
+			for _, v := range ret {
+				if v == radical {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				ret = append(ret, radical)
+			}
+		}
+	}
+
+	return ret
+}
+
 /*
  * Local variables:
  * tab-width: 4
@@ -75,8 +75,80 @@ var (
 	EightTrigramFiveElement = []int{
 		ElementEarth, ElementEarth, ElementWater, ElementWood, ElementWood, ElementFire, ElementMetal, ElementMetal,
 	}
+
+	// XiantianTrigrams : Eight trigrams of XianTian numbers 1 - 8, number 8 at 0
+	XiantianTrigrams = []int{
+		TrigramKun, TrigramQian, TrigramDui, TrigramLi, TrigramZhen, TrigramXun, TrigramKan, TrigramGen,
+	}
+
+	// Lines of eight trigrams, yang line as 1 and bottom line at lowest bit
+	trigramLines = []int{0, 4, 2, 6, 1, 5, 3, 7}
+
+	// KingWen orders of hexagrams, by upper & lower trigram
+	hexagramOrders = [][]int{
+		{2, 15, 7, 46, 24, 36, 19, 11},
+		{23, 52, 4, 18, 27, 22, 41, 26},
+		{8, 39, 29, 48, 3, 63, 60, 5},
+		{20, 53, 59, 57, 42, 37, 61, 9},
+		{16, 62, 40, 32, 51, 55, 54, 34},
+		{35, 56, 64, 50, 21, 30, 38, 14},
+		{45, 31, 47, 28, 17, 49, 58, 43},
+		{12, 33, 6, 44, 25, 13, 10, 1},
+	}
 )
 
+// Hexagram : Hexagram of upper and lower trigrams, 6 lines with bottom line at lowest bit
+type Hexagram struct {
+	Upper int
+	Lower int
+}
+
+func trigramOfLines(lines int) int {
+	for t, l := range trigramLines {
+		if l == lines&7 {
+			return t
+		}
+	}
+
+	return TrigramKun
+}
+
+func hexagramOfLines(lines int) Hexagram {
+	return Hexagram{
+		Upper: trigramOfLines(lines >> 3),
+		Lower: trigramOfLines(lines),
+	}
+}
+
+// Lines : Six lines of hexagram
+func (h Hexagram) Lines() int {
+	return trigramLines[h.Upper]<<3 | trigramLines[h.Lower]
+}
+
+// Order : KingWen order (1 - 64) of hexagram
+func (h Hexagram) Order() int {
+	return hexagramOrders[h.Upper][h.Lower]
+}
+
+// Mutual : Mutual hexagram (HuGua) of lines 2 - 4 and lines 3 - 5
+func (h Hexagram) Mutual() Hexagram {
+	lines := h.Lines()
+
+	return Hexagram{
+		Upper: trigramOfLines(lines >> 2),
+		Lower: trigramOfLines(lines >> 1),
+	}
+}
+
+// Changed : Changed hexagram (BianGua) with moving line (1 - 6) flipped
+func (h Hexagram) Changed(line int) Hexagram {
+	if line < 1 || line > 6 {
+		return h
+	}
+
+	return hexagramOfLines(h.Lines() ^ (1 << uint(line-1)))
+}
+
 /*
  * Local variables:
  * tab-width: 4
@@ -77,6 +77,57 @@ type FiveElementsCount struct {
 	Water int `json:"water"`
 }
 
+// Get : Get count of given five-element
+func (c *FiveElementsCount) Get(element int) int {
+	switch element {
+	case ElementWood:
+		return c.Wood
+	case ElementFire:
+		return c.Fire
+	case ElementEarth:
+		return c.Earth
+	case ElementMetal:
+		return c.Metal
+	case ElementWater:
+		return c.Water
+	}
+
+	return 0
+}
+
+// Add : Add n to count of given five-element
+func (c *FiveElementsCount) Add(element, n int) {
+	switch element {
+	case ElementWood:
+		c.Wood += n
+	case ElementFire:
+		c.Fire += n
+	case ElementEarth:
+		c.Earth += n
+	case ElementMetal:
+		c.Metal += n
+	case ElementWater:
+		c.Water += n
+	}
+}
+
+// MinMax : Minimum and maximum counts of all five-elements
+func (c *FiveElementsCount) MinMax() (int, int) {
+	min := c.Wood
+	max := c.Wood
+	for _, v := range []int{c.Fire, c.Earth, c.Metal, c.Water} {
+		if v < min {
+			min = v
+		}
+
+		if v > max {
+			max = v
+		}
+	}
+
+	return min, max
+}
+
 // CompareFiveElements : Compare five-elements - Wood->Fire->Earth->Metal->Water
 func CompareFiveElements(fe1, fe2 int) int {
 	if fe1 < fe2 {
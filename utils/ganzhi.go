@@ -142,6 +142,37 @@ func ZhiYinYang(zhi int) int {
 	return YinYangYin
 }
 
+// HiddenGan : Hidden tiangan (ZhiCang) of dizhi, with weight in percent
+type HiddenGan struct {
+	Gan    int `json:"gan"`
+	Weight int `json:"weight"`
+}
+
+// Hidden tiangans of dizhi, main qi first
+var zhiHiddenGans = [][]HiddenGan{
+	{{GanGui, 100}}, // 子
+	{{GanJi, 60}, {GanGui, 30}, {GanXin, 10}},  // 丑
+	{{GanJia, 60}, {GanBing, 30}, {GanWu, 10}}, // 寅
+	{{GanYi, 100}},                              // 卯
+	{{GanWu, 60}, {GanYi, 30}, {GanGui, 10}},    // 辰
+	{{GanBing, 60}, {GanWu, 30}, {GanGeng, 10}}, // 巳
+	{{GanDing, 70}, {GanJi, 30}},                // 午
+	{{GanJi, 60}, {GanDing, 30}, {GanYi, 10}},   // 未
+	{{GanGeng, 60}, {GanRen, 30}, {GanWu, 10}},  // 申
+	{{GanXin, 100}},                             // 酉
+	{{GanWu, 60}, {GanXin, 30}, {GanDing, 10}},  // 戌
+	{{GanRen, 70}, {GanJia, 30}},                // 亥
+}
+
+// ZhiHiddenGans : Hidden tiangans of dizhi
+func ZhiHiddenGans(zhi int) []HiddenGan {
+	if zhi < 0 || zhi >= len(zhiHiddenGans) {
+		return nil
+	}
+
+	return zhiHiddenGans[zhi]
+}
+
 // GanzhiPair : TianGan0DiZhi
 type GanzhiPair struct {
 	TianGan int `json:"tian_gan"`
@@ -187,6 +218,18 @@ func (gz *GanzhiPair) String(language int) string {
 		texts.GetAlias(texts.AliasZhi, gz.DiZhi, language))
 }
 
+// Void : Two dizhis without tiangan (KongWang) in the decade (XunShou) of GanzhiPair
+func (gz *GanzhiPair) Void() []int {
+	v := gz.Value()
+	if v < 0 {
+		return nil
+	}
+
+	start := v - v%10
+
+	return []int{(start + 10) % 12, (start + 11) % 12}
+}
+
 // ParseGanzhi : Parse int to GanzhiPair
 func ParseGanzhi(v int) *GanzhiPair {
 	return &GanzhiPair{
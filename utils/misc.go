@@ -30,12 +30,28 @@
 
 package utils
 
-import "bytes"
+import (
+	"bytes"
+	"math"
+)
 
 // Location : Geographic location
 type Location struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
+	Zone      string  `json:"zone,omitempty"`
+}
+
+// NormalizeLongitude : Longitude wrapped into (-180, 180], west negative
+func NormalizeLongitude(longitude float64) float64 {
+	longitude = math.Mod(longitude, 360)
+	if longitude > 180 {
+		longitude -= 360
+	} else if longitude <= -180 {
+		longitude += 360
+	}
+
+	return longitude
 }
 
 const (